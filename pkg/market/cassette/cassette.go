@@ -0,0 +1,319 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Mode describes what Recorder does with requests.
+type Mode int
+
+const (
+	// ModeReplay serves requests from cassette without network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to API and records interactions to cassette.
+	ModeRecord
+)
+
+const scrubbed = "[SCRUBBED]"
+
+// ErrNoInteraction is returned in replay mode when cassette has no interaction matching request.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction")
+
+// DefaultScrubbedHeaders are headers which values are never written to cassette.
+var DefaultScrubbedHeaders = []string{"Authorization", "Api-Key", "Cookie", "Set-Cookie"}
+
+// DefaultScrubbedQueryArgs are query args which values are never written to cassette.
+var DefaultScrubbedQueryArgs = []string{"oauth_token", "oauth_client_id", "access_token", "api_key"}
+
+// secretPattern matches oauth tokens embedded in bodies.
+var secretPattern = regexp.MustCompile(`(?i)("(?:access_token|refresh_token|api_key)"\s*:\s*")[^"]*`)
+
+// Cassette is a list of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request with its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request, URL contains only path and query.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Options recorder constructor params.
+type Options struct {
+	Transport         http.RoundTripper
+	ScrubbedHeaders   []string
+	ScrubbedQueryArgs []string
+}
+
+// Option modifies Options.
+type Option func(*Options)
+
+// WithTransport configures transport used to send requests in record mode.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *Options) {
+		o.Transport = transport
+	}
+}
+
+// WithScrubbedHeaders adds headers which values are never written to cassette.
+func WithScrubbedHeaders(headers ...string) Option {
+	return func(o *Options) {
+		o.ScrubbedHeaders = append(o.ScrubbedHeaders, headers...)
+	}
+}
+
+// WithScrubbedQueryArgs adds query args which values are never written to cassette.
+func WithScrubbedQueryArgs(args ...string) Option {
+	return func(o *Options) {
+		o.ScrubbedQueryArgs = append(o.ScrubbedQueryArgs, args...)
+	}
+}
+
+// Recorder is http.RoundTripper recording or replaying interactions.
+type Recorder struct {
+	path    string
+	mode    Mode
+	options *Options
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New is Recorder constructor, in replay mode cassette is loaded from path.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	opt := &Options{
+		Transport:         http.DefaultTransport,
+		ScrubbedHeaders:   append([]string{}, DefaultScrubbedHeaders...),
+		ScrubbedQueryArgs: append([]string{}, DefaultScrubbedQueryArgs...),
+	}
+
+	for _, o := range opts {
+		o(opt)
+	}
+
+	r := &Recorder{
+		path:    path,
+		mode:    mode,
+		options: opt,
+	}
+
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("unmarshal cassette: %w", err)
+		}
+
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns http client using recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := r.scrubRequest(req, body)
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.options.Transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	resp.Request = req
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubHeader(resp.Header),
+			Body:       scrubBody(string(respBody)),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes recorded interactions to cassette file, it does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("create cassette dir: %w", err)
+	}
+
+	if err := ioutil.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// replay returns response of the first unused interaction matching request.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+
+		r.used[i] = true
+		status := interaction.Response.StatusCode
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) scrubRequest(req *http.Request, body []byte) Request {
+	query := req.URL.Query()
+
+	for _, arg := range r.options.ScrubbedQueryArgs {
+		if _, ok := query[arg]; ok {
+			query.Set(arg, scrubbed)
+		}
+	}
+
+	u := url.URL{Path: req.URL.Path, RawQuery: query.Encode()}
+
+	return Request{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: r.scrubHeader(req.Header),
+		Body:   scrubBody(string(body)),
+	}
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	result := header.Clone()
+
+	for _, name := range r.options.ScrubbedHeaders {
+		if result.Get(name) != "" {
+			result.Set(name, scrubbed)
+		}
+	}
+
+	return result
+}
+
+func scrubBody(body string) string {
+	return secretPattern.ReplaceAllString(body, "${1}"+scrubbed)
+}
+
+// matches compares method, url and body of requests, JSON bodies are compared semantically.
+func matches(recorded, actual Request) bool {
+	if recorded.Method != actual.Method || recorded.URL != actual.URL {
+		return false
+	}
+
+	if recorded.Body == actual.Body {
+		return true
+	}
+
+	var recordedJSON, actualJSON interface{}
+	if json.Unmarshal([]byte(recorded.Body), &recordedJSON) != nil ||
+		json.Unmarshal([]byte(actual.Body), &actualJSON) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(recordedJSON, actualJSON)
+}
+
+// readBody reads and closes request body.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,3 @@
+// Package cassette contains http.RoundTripper that records API interactions to JSON files
+// and replays them back, so client tests can run without network access.
+package cassette
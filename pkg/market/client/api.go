@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// FeedsAPI manages campaign feeds.
+type FeedsAPI interface {
+	ListFeeds(ctx context.Context, campaignID int64, opts ...CallOption) ([]models.Feed, error)
+	RefreshFeed(ctx context.Context, campaignID, feedID int64, opts ...CallOption) error
+}
+
+// PricesAPI manages offer prices set with API.
+type PricesAPI interface {
+	SetOfferPrices(ctx context.Context, campaignID int64, offers []models.Offer, opts ...CallOption) error
+	SetOfferPricesBatched(
+		ctx context.Context,
+		campaignID int64,
+		offers []models.Offer,
+		opts ...BatchOption,
+	) (BatchResult, error)
+	GetOfferPrices(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.GetOfferPricesOption,
+	) ([]models.GetPriceOfferModel, error)
+	DeleteAllOffersPrices(ctx context.Context, campaignID int64, opts ...CallOption) error
+	DeleteOfferPrices(
+		ctx context.Context,
+		campaignID int64,
+		offers []models.OfferRef,
+		opts ...BatchOption,
+	) (BatchResult, error)
+}
+
+// BusinessPricesAPI manages offer prices set for all campaigns of a business.
+type BusinessPricesAPI interface {
+	SetBusinessOfferPrices(
+		ctx context.Context,
+		businessID int64,
+		offers []models.BusinessOfferPrice,
+		opts ...CallOption,
+	) error
+	GetBusinessOfferPrices(
+		ctx context.Context,
+		businessID int64,
+		opts ...models.GetBusinessPricesOption,
+	) (models.BusinessPricesResult, error)
+}
+
+// HiddenOffersAPI manages hidden offers.
+type HiddenOffersAPI interface {
+	HideOffers(ctx context.Context, campaignID int64, offersToHide []models.HiddenOffer, opts ...CallOption) error
+	GetHiddenOffers(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.GetHiddenOffersOption,
+	) (models.GetHiddenOfferResult, error)
+	UnhideOffers(ctx context.Context, campaignID int64, offersToUnhide []models.OfferToUnhide, opts ...CallOption) error
+}
+
+// OffersAPI searches campaign offers.
+type OffersAPI interface {
+	ExploreOffers(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.ExploreOption,
+	) (models.ExploreOffersResponse, error)
+}
+
+// MarketAPI is implemented by YandexMarketClient, depend on it or on smaller interfaces
+// to replace the client with mock.Client in tests.
+type MarketAPI interface {
+	FeedsAPI
+	PricesAPI
+	BusinessPricesAPI
+	HiddenOffersAPI
+	OffersAPI
+}
+
+var _ MarketAPI = (*YandexMarketClient)(nil)
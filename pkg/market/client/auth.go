@@ -0,0 +1,87 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Authenticator authorizes requests to API.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc is a function implementing Authenticator.
+type AuthenticatorFunc func(req *http.Request) error
+
+// Authenticate implements Authenticator.
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// OAuthAuthenticator authorizes requests with legacy
+// "OAuth oauth_token=..., oauth_client_id=..." authorization header.
+type OAuthAuthenticator struct {
+	Token    string
+	ClientID string
+}
+
+// Authenticate implements Authenticator.
+func (a OAuthAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("authorization", fmt.Sprintf("OAuth oauth_token=%s, oauth_client_id=%s", a.Token, a.ClientID))
+
+	return nil
+}
+
+// BearerAuthenticator authorizes requests with "Bearer" oauth token in authorization header.
+type BearerAuthenticator struct {
+	Token string
+}
+
+// Authenticate implements Authenticator.
+func (a BearerAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("authorization", "Bearer "+a.Token)
+
+	return nil
+}
+
+// APIKeyAuthenticator authorizes requests with Api-Key header.
+type APIKeyAuthenticator struct {
+	Key string
+}
+
+// Authenticate implements Authenticator.
+func (a APIKeyAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("Api-Key", a.Key)
+
+	return nil
+}
+
+// WithAuthenticator configures the way requests are authorized.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(o *Options) {
+		o.Authenticator = authenticator
+	}
+}
+
+// WithOAuth configures oauth clientID and token sent in legacy oauth authorization header.
+func WithOAuth(token, clientID string) Option {
+	return func(o *Options) {
+		o.OAuthClientID = clientID
+		o.OAuthToken = token
+		o.Authenticator = OAuthAuthenticator{Token: token, ClientID: clientID}
+	}
+}
+
+// WithBearerToken configures oauth token sent as "Bearer" authorization.
+func WithBearerToken(token string) Option {
+	return func(o *Options) {
+		o.Authenticator = BearerAuthenticator{Token: token}
+	}
+}
+
+// WithAPIKey configures api key sent in Api-Key header.
+func WithAPIKey(key string) Option {
+	return func(o *Options) {
+		o.Authenticator = APIKeyAuthenticator{Key: key}
+	}
+}
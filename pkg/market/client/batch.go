@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// DefaultChunkRetryPolicy is a policy of resubmitting failed chunks of batched calls.
+// Unlike DefaultRetryPolicy it resubmits POST chunks: chunk sets or deletes the same prices every time,
+// so sending it again after a transient failure does not change the result.
+var DefaultChunkRetryPolicy = RetryPolicy{
+	MaxAttempts:        3,
+	BaseDelay:          500 * time.Millisecond,
+	MaxDelay:           30 * time.Second,
+	RetryNonIdempotent: true,
+}
+
+// ErrBatchCallRetryPolicy is returned when retry policy is passed with batch call options.
+var ErrBatchCallRetryPolicy = errors.New("chunk calls are not retried by call retry policy, use WithChunkRetryPolicy")
+
+// BatchOptions configures batched calls.
+type BatchOptions struct {
+	// ChunkSize is a number of offers sent in a single call, it is capped by API limit.
+	ChunkSize int
+	// Workers is a number of chunks sent concurrently, values below 1 mean chunks are sent one by one.
+	Workers int
+	// RetryPolicy describes how chunks failed with transient errors are resubmitted,
+	// chunks of POST calls are resubmitted only if RetryNonIdempotent is set.
+	// Client retry policy and retry policy passed with WithCallOptions are not applied to chunk calls,
+	// so a chunk is sent at most RetryPolicy.MaxAttempts times.
+	RetryPolicy RetryPolicy
+	// CallOptions are applied to every chunk call, they must not set retry policy.
+	CallOptions []CallOption
+}
+
+// BatchOption modifies BatchOptions.
+type BatchOption func(*BatchOptions)
+
+// WithChunkSize sets number of offers sent in a single call.
+func WithChunkSize(size int) BatchOption {
+	return func(o *BatchOptions) {
+		o.ChunkSize = size
+	}
+}
+
+// WithBatchWorkers sets number of chunks sent concurrently, values below 1 mean chunks are sent one by one.
+func WithBatchWorkers(workers int) BatchOption {
+	return func(o *BatchOptions) {
+		o.Workers = workers
+	}
+}
+
+// WithChunkRetryPolicy sets policy of resubmitting failed chunks.
+// Chunks of POST calls are resubmitted only if policy has RetryNonIdempotent set.
+func WithChunkRetryPolicy(policy RetryPolicy) BatchOption {
+	return func(o *BatchOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithBatchCallOptions sets options applied to every chunk call.
+// Batched call fails with ErrBatchCallRetryPolicy if options set retry policy.
+func WithBatchCallOptions(opts ...CallOption) BatchOption {
+	return func(o *BatchOptions) {
+		o.CallOptions = append(o.CallOptions, opts...)
+	}
+}
+
+// ChunkResult is a result of a single chunk of batched call.
+type ChunkResult struct {
+	Index int
+	// OfferIDs are ids of offers sent in the chunk.
+	OfferIDs []string
+	// Attempts is a number of times the chunk was submitted.
+	Attempts int
+	// Status is a status reported by API in the last attempt, empty if API reported no status.
+	Status models.Status
+	// Errors are errors reported by API in the last attempt.
+	Errors models.CommonErrors
+	Err    error
+}
+
+// BatchResult is a result of batched call.
+type BatchResult struct {
+	Chunks []ChunkResult
+}
+
+// FailedChunks returns results of failed chunks.
+func (r BatchResult) FailedChunks() []ChunkResult {
+	var failed []ChunkResult
+
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			failed = append(failed, chunk)
+		}
+	}
+
+	return failed
+}
+
+// ChunkErrorsByOffer maps ids of offers from failed chunks to errors of their chunk.
+// API reports errors per call without offer ids, so every offer of a failed chunk gets all errors of the chunk,
+// including errors caused by other offers of the chunk.
+// Chunks failed without errors reported by API are described with error message only.
+func (r BatchResult) ChunkErrorsByOffer() map[string]models.CommonErrors {
+	result := make(map[string]models.CommonErrors)
+
+	for _, chunk := range r.FailedChunks() {
+		errs := chunk.Errors
+		if len(errs) == 0 {
+			errs = models.CommonErrors{{Message: chunk.Err.Error()}}
+		}
+
+		for _, id := range chunk.OfferIDs {
+			result[id] = errs
+		}
+	}
+
+	return result
+}
+
+// Err returns error describing failed chunks, nil if all chunks succeeded.
+func (r BatchResult) Err() error {
+	failed := r.FailedChunks()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d chunks failed, first: chunk %d: %w",
+		len(failed), len(r.Chunks), failed[0].Index, failed[0].Err)
+}
+
+// runBatched splits offers with ids into chunks of at most limit offers and sends them with send.
+func (c *YandexMarketClient) runBatched(
+	ctx context.Context,
+	campaignID int64,
+	name string,
+	ids []string,
+	limit int,
+	opts []BatchOption,
+	send func(ctx context.Context, campaign *CampaignClient, from, to int, opts ...CallOption) error,
+) (BatchResult, error) {
+	o := BatchOptions{
+		ChunkSize:   limit,
+		Workers:     1,
+		RetryPolicy: DefaultChunkRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.ChunkSize <= 0 || o.ChunkSize > limit {
+		o.ChunkSize = limit
+	}
+
+	if o.Workers < 1 {
+		o.Workers = 1
+	}
+
+	chunkCallOpts := CallOptions{}
+	for _, opt := range o.CallOptions {
+		opt(&chunkCallOpts)
+	}
+
+	if chunkCallOpts.RetryPolicy != nil {
+		return BatchResult{}, ErrBatchCallRetryPolicy
+	}
+
+	// chunks are resubmitted by sendChunk, client retries would multiply attempts.
+	callOpts := append(append([]CallOption{}, o.CallOptions...), WithCallRetryPolicy(NoRetries))
+
+	var operations []BulkOperation
+
+	for from := 0; from < len(ids); from += o.ChunkSize {
+		from, to, index := from, from+o.ChunkSize, len(operations)
+		if to > len(ids) {
+			to = len(ids)
+		}
+
+		operations = append(operations, BulkOperation{
+			CampaignID: campaignID,
+			Name:       name,
+			Run: func(ctx context.Context, campaign *CampaignClient) (interface{}, error) {
+				chunk := c.sendChunk(ctx, o, func(ctx context.Context) error {
+					return send(ctx, campaign, from, to, callOpts...)
+				})
+				chunk.Index = index
+				chunk.OfferIDs = ids[from:to]
+
+				return chunk, chunk.Err
+			},
+		})
+	}
+
+	result := BatchResult{Chunks: make([]ChunkResult, len(operations))}
+
+	for i, bulkResult := range NewBulkExecutor(c, o.Workers).Run(ctx, operations) {
+		chunk, ok := bulkResult.Value.(ChunkResult)
+		if !ok {
+			// chunk was not sent before context was done.
+			from := i * o.ChunkSize
+
+			to := from + o.ChunkSize
+			if to > len(ids) {
+				to = len(ids)
+			}
+
+			chunk = ChunkResult{Index: i, OfferIDs: ids[from:to], Err: bulkResult.Err}
+		}
+
+		result.Chunks[i] = chunk
+	}
+
+	return result, result.Err()
+}
+
+// sendChunk sends chunk and resubmits it while it fails with transient errors.
+func (c *YandexMarketClient) sendChunk(
+	ctx context.Context,
+	o BatchOptions,
+	send func(context.Context) error,
+) ChunkResult {
+	chunk := ChunkResult{}
+
+	for {
+		chunk.Attempts++
+
+		err := send(ctx)
+
+		chunk.Err = err
+		chunk.Errors = nil
+		chunk.Status = ""
+
+		var errs models.CommonErrors
+
+		switch {
+		case err == nil:
+			chunk.Status = models.StatusOk
+		case errors.As(err, &errs):
+			chunk.Status = models.StatusError
+			chunk.Errors = errs
+		}
+
+		if !c.shouldResubmit(ctx, o.RetryPolicy, chunk) {
+			return chunk
+		}
+
+		delay := o.RetryPolicy.delay(chunk.Attempts, err)
+
+		c.options.Logger.Debug("resubmitting chunk",
+			zap.Int("attempt", chunk.Attempts),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return chunk
+		}
+	}
+}
+
+// shouldResubmit reports whether failed chunk should be sent again.
+func (c *YandexMarketClient) shouldResubmit(ctx context.Context, policy RetryPolicy, chunk ChunkResult) bool {
+	if chunk.Err == nil || chunk.Attempts >= policy.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if !policy.RetryNonIdempotent {
+		// chunks are sent with POST.
+		return false
+	}
+
+	var apiErr *APIError
+	if chunk.Status == models.StatusError && !errors.As(chunk.Err, &apiErr) {
+		// API accepted the request and rejected offers.
+		return false
+	}
+
+	return isTransient(chunk.Err) &&
+		!errors.Is(chunk.Err, ErrCircuitOpen) &&
+		!errors.Is(chunk.Err, ErrTooManyItems) &&
+		!errors.Is(chunk.Err, ErrSchemaDrift)
+}
@@ -0,0 +1,298 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CircuitState is a state of circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all calls through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails calls without sending them.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe calls through.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is returned when call is rejected by open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned when call is rejected by open circuit breaker.
+type CircuitOpenError struct {
+	// Group is a method group of the circuit, empty for global circuit breaker.
+	Group string
+	// RetryAfter is a time left until the circuit lets probe calls through.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *CircuitOpenError) Error() string {
+	if e.Group == "" {
+		return ErrCircuitOpen.Error()
+	}
+
+	return fmt.Sprintf("%s for %s", ErrCircuitOpen, e.Group)
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// DefaultCircuitBreaker is a circuit breaker opened when half of at least 10 calls in a minute fail.
+var DefaultCircuitBreaker = CircuitBreaker{
+	FailureRatio:   0.5,
+	MinRequests:    10,
+	Window:         time.Minute,
+	OpenTimeout:    30 * time.Second,
+	HalfOpenProbes: 1,
+}
+
+// CircuitBreaker describes when calls fail fast without reaching API.
+// Network errors, timeouts and 5xx responses are failures, other responses are successes.
+type CircuitBreaker struct {
+	// PerGroup enables separate circuits per method group, see RateGroup* constants.
+	// Otherwise single circuit is used for all calls.
+	PerGroup bool
+	// FailureRatio is a ratio of failed attempts in Window that opens the circuit.
+	FailureRatio float64
+	// MinRequests is a minimum number of attempts in Window to open the circuit.
+	MinRequests int
+	// Window is a period failures are counted in.
+	Window time.Duration
+	// OpenTimeout is a time circuit stays open before letting probe calls through.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is a number of successful probe calls that close the circuit,
+	// a single failed probe opens it again.
+	HalfOpenProbes int
+}
+
+// CircuitStateRecorder is implemented by metrics recorders tracking circuit breaker state.
+type CircuitStateRecorder interface {
+	RecordCircuitState(group string, state CircuitState)
+}
+
+// WithCircuitBreaker enables circuit breaker.
+// State changes are logged and passed to metrics recorder if it implements CircuitStateRecorder.
+func WithCircuitBreaker(breaker CircuitBreaker) Option {
+	return func(o *Options) {
+		o.CircuitBreaker = &breaker
+	}
+}
+
+type circuit struct {
+	state CircuitState
+
+	windowStart time.Time
+	requests    int
+	failures    int
+
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+// circuitBreakers keeps circuits of method groups.
+type circuitBreakers struct {
+	settings CircuitBreaker
+	onChange func(group string, from, to CircuitState)
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newCircuitBreakers(settings CircuitBreaker, onChange func(group string, from, to CircuitState)) *circuitBreakers {
+	if settings.HalfOpenProbes <= 0 {
+		settings.HalfOpenProbes = 1
+	}
+
+	return &circuitBreakers{
+		settings: settings,
+		onChange: onChange,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+func (b *circuitBreakers) circuit(group string) (string, *circuit) {
+	if !b.settings.PerGroup {
+		group = ""
+	}
+
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{}
+		b.circuits[group] = c
+	}
+
+	return group, c
+}
+
+// allow reports whether attempt may be sent, returns *CircuitOpenError otherwise.
+func (b *circuitBreakers) allow(group string) error {
+	if b == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	b.mu.Lock()
+
+	key, c := b.circuit(group)
+	from := c.state
+
+	var err error
+
+	switch c.state {
+	case CircuitOpen:
+		if wait := c.openedAt.Add(b.settings.OpenTimeout).Sub(now); wait > 0 {
+			err = &CircuitOpenError{Group: key, RetryAfter: wait}
+
+			break
+		}
+
+		c.state = CircuitHalfOpen
+		c.probes = 1
+		c.successes = 0
+	case CircuitHalfOpen:
+		if c.probes >= b.settings.HalfOpenProbes {
+			err = &CircuitOpenError{Group: key}
+
+			break
+		}
+
+		c.probes++
+	case CircuitClosed:
+	}
+
+	to := c.state
+
+	b.mu.Unlock()
+
+	b.changed(key, from, to)
+
+	return err
+}
+
+// done records result of allowed attempt.
+func (b *circuitBreakers) done(group string, err error) {
+	if b == nil {
+		return
+	}
+
+	now := time.Now()
+	failed := isCircuitFailure(err)
+
+	b.mu.Lock()
+
+	key, c := b.circuit(group)
+	from := c.state
+
+	switch c.state {
+	case CircuitHalfOpen:
+		switch {
+		case errors.Is(err, context.Canceled):
+			c.probes--
+		case failed:
+			b.open(c, now)
+		default:
+			c.successes++
+			if c.successes >= b.settings.HalfOpenProbes {
+				c.state = CircuitClosed
+				c.windowStart = now
+				c.requests = 0
+				c.failures = 0
+			}
+		}
+	case CircuitClosed:
+		if errors.Is(err, context.Canceled) {
+			break
+		}
+
+		if now.Sub(c.windowStart) >= b.settings.Window {
+			c.windowStart = now
+			c.requests = 0
+			c.failures = 0
+		}
+
+		c.requests++
+
+		if failed {
+			c.failures++
+		}
+
+		if c.requests >= b.settings.MinRequests &&
+			float64(c.failures) >= b.settings.FailureRatio*float64(c.requests) && c.failures > 0 {
+			b.open(c, now)
+		}
+	case CircuitOpen:
+	}
+
+	to := c.state
+
+	b.mu.Unlock()
+
+	b.changed(key, from, to)
+}
+
+func (b *circuitBreakers) open(c *circuit, now time.Time) {
+	c.state = CircuitOpen
+	c.openedAt = now
+	c.probes = 0
+	c.successes = 0
+}
+
+func (b *circuitBreakers) changed(group string, from, to CircuitState) {
+	if from != to && b.onChange != nil {
+		b.onChange(group, from, to)
+	}
+}
+
+// isCircuitFailure reports whether attempt failed because API is unavailable.
+func isCircuitFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// circuitStateChanged reports circuit breaker state change.
+func (c *YandexMarketClient) circuitStateChanged(group string, from, to CircuitState) {
+	c.options.Logger.Warn("yandex market circuit breaker state changed",
+		zap.String("group", group),
+		zap.Stringer("from", from),
+		zap.Stringer("to", to),
+	)
+
+	if recorder, ok := c.options.Metrics.(CircuitStateRecorder); ok {
+		recorder.RecordCircuitState(group, to)
+	}
+}
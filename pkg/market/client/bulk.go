@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// DefaultBulkWorkers is a default number of operations run concurrently by BulkExecutor.
+const DefaultBulkWorkers = 4
+
+// BulkOperation is a campaign scoped operation run by BulkExecutor.
+type BulkOperation struct {
+	CampaignID int64
+	// Name describes operation in results, like "SetOfferPrices".
+	Name string
+	// Run calls API with client scoped to the campaign, returned value is stored in BulkResult.
+	Run func(ctx context.Context, campaign *CampaignClient) (interface{}, error)
+}
+
+// BulkResult is a result of BulkOperation.
+type BulkResult struct {
+	Operation BulkOperation
+	// Value is a value returned by operation, like []models.Feed of ListFeeds operation.
+	Value interface{}
+	// Err is an error of operation, operations not started before context is done fail with context error.
+	Err error
+}
+
+// BulkResults are results of operations in the order operations were passed.
+type BulkResults []BulkResult
+
+// Failed returns results of failed operations.
+func (r BulkResults) Failed() BulkResults {
+	var failed BulkResults
+
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// Err returns error describing failed operations, nil if all operations succeeded.
+func (r BulkResults) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d bulk operations failed, first: %s for campaign %d: %w",
+		len(failed), len(r), failed[0].Operation.Name, failed[0].Operation.CampaignID, failed[0].Err)
+}
+
+// BulkExecutor runs campaign scoped operations concurrently.
+// Operations are rate limited by client rate limiter.
+type BulkExecutor struct {
+	client  *YandexMarketClient
+	workers int
+}
+
+// NewBulkExecutor is BulkExecutor constructor, workers less than 1 means DefaultBulkWorkers.
+func NewBulkExecutor(client *YandexMarketClient, workers int) *BulkExecutor {
+	if workers < 1 {
+		workers = DefaultBulkWorkers
+	}
+
+	return &BulkExecutor{client: client, workers: workers}
+}
+
+// Run runs all operations and returns their results, failed operations do not stop the rest.
+// When context is done operations not started yet are not run.
+func (e *BulkExecutor) Run(ctx context.Context, operations []BulkOperation) BulkResults {
+	results := make(BulkResults, len(operations))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	workers := e.workers
+	if workers > len(operations) {
+		workers = len(operations)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				op := operations[idx]
+				value, err := op.Run(ctx, e.client.Campaign(op.CampaignID))
+				results[idx] = BulkResult{Operation: op, Value: value, Err: err}
+			}
+		}()
+	}
+
+	next := 0
+
+loop:
+	for ; next < len(operations); next++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case indexes <- next:
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	for ; next < len(operations); next++ {
+		results[next] = BulkResult{Operation: operations[next], Err: ctx.Err()}
+	}
+
+	return results
+}
+
+// BulkListFeeds returns operation listing campaign feeds, its value is []models.Feed.
+func BulkListFeeds(campaignID int64, opts ...CallOption) BulkOperation {
+	return BulkOperation{
+		CampaignID: campaignID,
+		Name:       "ListFeeds",
+		Run: func(ctx context.Context, campaign *CampaignClient) (interface{}, error) {
+			return campaign.Feeds().List(ctx, opts...)
+		},
+	}
+}
+
+// BulkSetOfferPrices returns operation setting campaign offer prices.
+func BulkSetOfferPrices(campaignID int64, offers []models.Offer, opts ...CallOption) BulkOperation {
+	return BulkOperation{
+		CampaignID: campaignID,
+		Name:       "SetOfferPrices",
+		Run: func(ctx context.Context, campaign *CampaignClient) (interface{}, error) {
+			return nil, campaign.Prices().Set(ctx, offers, opts...)
+		},
+	}
+}
+
+// BulkHideOffers returns operation hiding campaign offers.
+func BulkHideOffers(campaignID int64, offersToHide []models.HiddenOffer, opts ...CallOption) BulkOperation {
+	return BulkOperation{
+		CampaignID: campaignID,
+		Name:       "HideOffers",
+		Run: func(ctx context.Context, campaign *CampaignClient) (interface{}, error) {
+			return nil, campaign.HiddenOffers().Hide(ctx, offersToHide, opts...)
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// BusinessPricesService manages offer prices set for all campaigns of a business.
+// Business endpoints accept only json, so its calls are sent in json whatever client format is.
+type BusinessPricesService struct {
+	client     *YandexMarketClient
+	businessID int64
+}
+
+// Prices returns business offer prices service.
+func (b *BusinessClient) Prices() *BusinessPricesService {
+	return &BusinessPricesService{client: b.client, businessID: b.id}
+}
+
+// Set sets prices of offers in all campaigns of the business.
+// In single call allowed to set no more than 500 offers.
+func (s *BusinessPricesService) Set(ctx context.Context, offers []models.BusinessOfferPrice, opts ...CallOption) error {
+	ctx = withJSONOnly(withOperation(WithCallOptions(ctx, opts...), "SetBusinessOfferPrices", len(offers)))
+
+	requestBody, err := FormatJSON.marshal(models.SetBusinessPricesRequest{Offers: offers})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/businesses/%d/offer-prices/updates", s.businessID),
+		nil,
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	setPriceResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, setPriceResponse)
+	if err != nil {
+		return err
+	}
+
+	if setPriceResponse.Status.IsError() {
+		return fmt.Errorf("failed to set business prices: %w", setPriceResponse.Errors)
+	}
+
+	return nil
+}
+
+// List returns prices of business offers.
+// Call options are passed with WithCallOptions.
+func (s *BusinessPricesService) List(
+	ctx context.Context,
+	opts ...models.GetBusinessPricesOption,
+) (models.BusinessPricesResult, error) {
+	ctx = withJSONOnly(withOperation(ctx, "GetBusinessOfferPrices", 0))
+
+	o := models.GetBusinessPricesOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	requestBody, err := FormatJSON.marshal(o.ToRequest())
+	if err != nil {
+		return models.BusinessPricesResult{}, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/businesses/%d/offer-prices", s.businessID),
+		o.ToQueryArgs(),
+		requestBody)
+	if err != nil {
+		return models.BusinessPricesResult{}, err
+	}
+
+	getPricesResponse := &models.GetBusinessPricesResponse{}
+
+	err = s.client.executeRequest(req, getPricesResponse)
+	if err != nil {
+		return models.BusinessPricesResult{}, err
+	}
+
+	if getPricesResponse.Status.IsError() {
+		return models.BusinessPricesResult{}, fmt.Errorf("failed to get business prices: %w", getPricesResponse.Errors)
+	}
+
+	return getPricesResponse.Result, nil
+}
@@ -0,0 +1,251 @@
+package client
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is a default number of responses kept in LRUCache.
+const DefaultCacheSize = 1024
+
+// DefaultCacheTTLs are default time to live of cached responses of slow-changing methods.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"ListFeeds": 10 * time.Minute,
+}
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	Body []byte
+	// ETag is used to revalidate entry after it expires.
+	ETag    string
+	Expires time.Time
+	// Header is a header of cached response.
+	Header http.Header
+}
+
+// CacheStore stores cached responses.
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	// DeletePrefix deletes all entries with keys starting with prefix.
+	DeletePrefix(prefix string)
+}
+
+// WithResponseCache enables caching of GET responses.
+// TTLs maps client method name like "ListFeeds" to time to live of its responses,
+// methods missing in TTLs are not cached, nil TTLs means DefaultCacheTTLs.
+// Nil store means LRUCache of DefaultCacheSize created for every client the option is applied to.
+// Entries are not keyed by credentials, so clients sharing the store must use the same ones.
+// Mutating calls invalidate cached responses of the same campaign and method group.
+func WithResponseCache(store CacheStore, ttls map[string]time.Duration) Option {
+	if ttls == nil {
+		ttls = DefaultCacheTTLs
+	}
+
+	return func(o *Options) {
+		o.CacheStore = store
+		if o.CacheStore == nil {
+			o.CacheStore = NewLRUCache(DefaultCacheSize)
+		}
+
+		o.CacheTTLs = ttls
+	}
+}
+
+// responseCache caches API responses according to per method TTLs.
+type responseCache struct {
+	store CacheStore
+	ttls  map[string]time.Duration
+}
+
+func newResponseCache(store CacheStore, ttls map[string]time.Duration) *responseCache {
+	if store == nil {
+		return nil
+	}
+
+	return &responseCache{store: store, ttls: ttls}
+}
+
+func cacheKey(info CallInfo, req *http.Request) string {
+	return cachePrefix(info) + req.URL.RequestURI()
+}
+
+func cachePrefix(info CallInfo) string {
+	return fmt.Sprintf("%d/%s/", info.CampaignID, info.Group)
+}
+
+// lookup returns fresh cached response.
+// If cached response is expired but has ETag, request is prepared for revalidation.
+func (c *responseCache) lookup(info CallInfo, req *http.Request) (*Response, bool) {
+	if c == nil || req.Method != http.MethodGet || c.ttls[info.Operation] <= 0 {
+		return nil, false
+	}
+
+	entry, ok := c.store.Get(cacheKey(info, req))
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().Before(entry.Expires) {
+		return cachedResponse(req, entry), true
+	}
+
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	return nil, false
+}
+
+// cachedResponse makes response to request served from cache entry, its body is already read.
+func cachedResponse(req *http.Request, entry CacheEntry) *Response {
+	return &Response{
+		HTTP: &http.Response{
+			Status:        http.StatusText(http.StatusOK),
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        entry.Header.Clone(),
+			Body:          http.NoBody,
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		},
+		Body: entry.Body,
+	}
+}
+
+// update stores response of successful GET call, invalidates entries after mutating calls
+// and returns body to decode.
+func (c *responseCache) update(info CallInfo, req *http.Request, resp *Response, err error) ([]byte, error) {
+	var body []byte
+	if resp != nil {
+		body = resp.Body
+	}
+
+	if c == nil {
+		return body, err
+	}
+
+	if req.Method != http.MethodGet {
+		c.store.DeletePrefix(cachePrefix(info))
+
+		return body, err
+	}
+
+	ttl := c.ttls[info.Operation]
+	if ttl <= 0 {
+		return body, err
+	}
+
+	key := cacheKey(info, req)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotModified {
+		entry, ok := c.store.Get(key)
+		if !ok {
+			return body, err
+		}
+
+		entry.Expires = time.Now().Add(ttl)
+		c.store.Set(key, entry)
+
+		return entry.Body, nil
+	}
+
+	if err != nil {
+		return body, err
+	}
+
+	c.store.Set(key, CacheEntry{
+		Body:    body,
+		ETag:    resp.HTTP.Header.Get("ETag"),
+		Expires: time.Now().Add(ttl),
+		Header:  resp.HTTP.Header.Clone(),
+	})
+
+	return body, nil
+}
+
+// LRUCache is an in-memory CacheStore that evicts least recently used entries.
+type LRUCache struct {
+	size int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+func lruItemOf(el *list.Element) *lruItem {
+	item, _ := el.Value.(*lruItem)
+
+	return item
+}
+
+// NewLRUCache is LRUCache constructor.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get implements CacheStore.
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return lruItemOf(el).entry, true
+}
+
+// Set implements CacheStore.
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		lruItemOf(el).entry = entry
+		c.ll.MoveToFront(el)
+
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&lruItem{key: key, entry: entry})
+
+	for c.size > 0 && c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, lruItemOf(oldest).key)
+	}
+}
+
+// DeletePrefix implements CacheStore.
+func (c *LRUCache) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.ll.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
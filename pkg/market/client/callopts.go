@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKeyHeader is a header carrying idempotency key of a call.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// CallOptions are params of a single API call overriding client Options.
+type CallOptions struct {
+	// Timeout limits duration of the call including retries, zero means no limit.
+	Timeout time.Duration
+	// RetryPolicy replaces client retry policy if set.
+	RetryPolicy *RetryPolicy
+	// IdempotencyKey is sent with IdempotencyKeyHeader,
+	// calls with idempotency key are retried as idempotent ones.
+	IdempotencyKey string
+	// Header is added to request headers.
+	Header http.Header
+	// Response receives raw response of the last attempt.
+	Response **http.Response
+	// DryRun overrides client dry-run mode if set.
+	DryRun *bool
+	// JSONOnly sends the call in json without format suffix in resource path.
+	JSONOnly bool
+}
+
+// CallOption modifies CallOptions.
+type CallOption func(*CallOptions)
+
+// WithCallTimeout limits duration of the call including retries.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *CallOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithCallRetryPolicy overrides client retry policy for the call.
+func WithCallRetryPolicy(policy RetryPolicy) CallOption {
+	return func(o *CallOptions) {
+		o.RetryPolicy = &policy
+	}
+}
+
+// WithIdempotencyKey sends idempotency key with the call and allows to retry it.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *CallOptions) {
+		o.IdempotencyKey = key
+	}
+}
+
+// WithHeader adds header to the call request.
+func WithHeader(name, value string) CallOption {
+	return func(o *CallOptions) {
+		if o.Header == nil {
+			o.Header = http.Header{}
+		}
+
+		o.Header.Add(name, value)
+	}
+}
+
+// WithRawResponse stores raw response of the call to dst, its body is already read.
+// Dst is left unchanged if no response is received.
+func WithRawResponse(dst **http.Response) CallOption {
+	return func(o *CallOptions) {
+		o.Response = dst
+	}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns context carrying call options, so they are applied to calls made with it.
+// It is useful for methods that accept variadic model options, like ExploreOffers.
+// Options passed to a method directly are applied after options from context.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	parent, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+
+	return context.WithValue(ctx, callOptionsKey{}, append(append([]CallOption{}, parent...), opts...))
+}
+
+// callOptionsFrom collects call options stored in context.
+func callOptionsFrom(ctx context.Context) CallOptions {
+	o := CallOptions{}
+
+	opts, _ := ctx.Value(callOptionsKey{}).([]CallOption)
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,53 @@
+package client
+
+// CampaignClient calls API methods of a single campaign.
+type CampaignClient struct {
+	client *YandexMarketClient
+	id     int64
+}
+
+// Campaign returns client scoped to campaign.
+func (c *YandexMarketClient) Campaign(campaignID int64) *CampaignClient {
+	return &CampaignClient{client: c, id: campaignID}
+}
+
+// ID returns campaign id.
+func (c *CampaignClient) ID() int64 {
+	return c.id
+}
+
+// Feeds returns campaign feeds service.
+func (c *CampaignClient) Feeds() *FeedsService {
+	return &FeedsService{client: c.client, campaignID: c.id}
+}
+
+// Prices returns campaign offer prices service.
+func (c *CampaignClient) Prices() *PricesService {
+	return &PricesService{client: c.client, campaignID: c.id}
+}
+
+// HiddenOffers returns campaign hidden offers service.
+func (c *CampaignClient) HiddenOffers() *HiddenOffersService {
+	return &HiddenOffersService{client: c.client, campaignID: c.id}
+}
+
+// Offers returns campaign offers service.
+func (c *CampaignClient) Offers() *OffersService {
+	return &OffersService{client: c.client, campaignID: c.id}
+}
+
+// BusinessClient calls API methods of a business, business unites campaigns of a seller.
+type BusinessClient struct {
+	client *YandexMarketClient
+	id     int64
+}
+
+// Business returns client scoped to business.
+func (c *YandexMarketClient) Business(businessID int64) *BusinessClient {
+	return &BusinessClient{client: c, id: businessID}
+}
+
+// ID returns business id.
+func (b *BusinessClient) ID() int64 {
+	return b.id
+}
@@ -1,14 +1,19 @@
 package client
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
 )
 
 const (
@@ -21,8 +26,12 @@ const (
 
 // YandexMarketClient wraps API calls to yandex market.
 type YandexMarketClient struct {
-	options    *Options
-	authHeader string
+	options  *Options
+	limiter  *rateLimiter
+	breakers *circuitBreakers
+	quota    *QuotaTracker
+	doer     Doer
+	cache    *responseCache
 }
 
 // Options client constructor params.
@@ -33,19 +42,32 @@ type Options struct {
 	UserAgent     string
 	Client        *http.Client
 	Logger        *zap.Logger
+	Authenticator Authenticator
+	RetryPolicy   RetryPolicy
+	RateLimits    RateLimits
+	Middlewares   []Middleware
+	Metrics       MetricsRecorder
+	Tracer        Tracer
+	CacheStore    CacheStore
+	CacheTTLs     map[string]time.Duration
+
+	RequestLogging RequestLogging
+
+	DryRun        bool
+	DryRunHandler DryRunHandler
+
+	Format         Format
+	StrictDecoding *StrictDecoding
+
+	LowQuotaThreshold float64
+	LowQuotaCallback  QuotaCallback
+
+	CircuitBreaker *CircuitBreaker
 }
 
 // Option modifies Options.
 type Option func(*Options)
 
-// WithOAuth configures oauth clientID and token.
-func WithOAuth(token, clientID string) Option {
-	return func(o *Options) {
-		o.OAuthClientID = clientID
-		o.OAuthToken = token
-	}
-}
-
 // WithLogger configures logger.
 func WithLogger(logger *zap.Logger) Option {
 	return func(o *Options) {
@@ -81,53 +103,273 @@ func NewYandexMarketClient(opts ...Option) *YandexMarketClient {
 		APIEndpoint: DefaultAPIEndpoint,
 		Logger:      zap.NewNop(),
 		UserAgent:   DefaultUserAgent,
+		RetryPolicy: DefaultRetryPolicy,
+		RateLimits:  DefaultRateLimits,
+		Format:      FormatJSON,
 	}
 
 	for _, o := range opts {
 		o(opt)
 	}
 
-	return &YandexMarketClient{
+	if opt.Authenticator == nil {
+		opt.Authenticator = OAuthAuthenticator{Token: opt.OAuthToken, ClientID: opt.OAuthClientID}
+	}
+
+	c := &YandexMarketClient{
 		options: opt,
-		authHeader: fmt.Sprintf("OAuth oauth_token=%s, oauth_client_id=%s",
-			opt.OAuthToken, opt.OAuthClientID),
+		quota:   NewQuotaTracker(opt.LowQuotaThreshold, opt.LowQuotaCallback),
+		cache:   newResponseCache(opt.CacheStore, opt.CacheTTLs),
+	}
+
+	if opt.RateLimits != nil {
+		c.limiter = newRateLimiter(opt.RateLimits)
+	}
+
+	if opt.CircuitBreaker != nil {
+		c.breakers = newCircuitBreakers(*opt.CircuitBreaker, c.circuitStateChanged)
+	}
+
+	middlewares := append([]Middleware{}, opt.Middlewares...)
+	if opt.RequestLogging.Level > RequestLogOff {
+		// logging is the innermost middleware, so it logs requests exactly as they are sent.
+		middlewares = append(middlewares, loggingMiddleware(opt.Logger, opt.RequestLogging))
 	}
+
+	c.doer = chainMiddlewares(DoerFunc(c.send), middlewares)
+
+	return c
 }
 
 func (c *YandexMarketClient) newRequest(
 	ctx context.Context,
 	method, reqPath string,
 	query url.Values,
-	body io.Reader,
+	body []byte,
 ) (*http.Request, error) {
 	fullURL, err := url.ParseRequestURI(c.options.APIEndpoint)
 	if err != nil {
 		return nil, fmt.Errorf("url parse request uri: %w", err)
 	}
 
-	fullURL.Path = reqPath + ".json"
+	format, suffix := c.formatOf(ctx)
+
+	fullURL.Path = reqPath + suffix
 	fullURL.RawQuery = query.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), body)
+	var bodyReader io.Reader
+	if body != nil {
+		// bytes.Reader lets http.NewRequest set GetBody, so the request can be retried.
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new request: %w", err)
 	}
 
-	req.Header.Add("authorization", c.authHeader)
 	req.Header.Add("user-agent", c.options.UserAgent)
 	req.Header.Add("accept", "*/*")
 
+	if body != nil {
+		req.Header.Set("content-type", format.contentType())
+	}
+
+	callOpts := callOptionsFrom(ctx)
+
+	for name, values := range callOpts.Header {
+		req.Header.Del(name)
+
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if callOpts.IdempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, callOpts.IdempotencyKey)
+	}
+
+	if err := c.options.Authenticator.Authenticate(req); err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+
 	return req, nil
 }
 
 func (c *YandexMarketClient) executeRequest(req *http.Request, jsonResponse interface{}) error {
-	resp, err := c.options.Client.Do(req)
+	info := newCallInfo(req)
+	callOpts := callOptionsFrom(req.Context())
+
+	if callOpts.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), callOpts.Timeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
+	if c.isDryRun(req, callOpts) {
+		return c.executeDryRun(info, req, jsonResponse)
+	}
+
+	finish := func(CallEvent) {}
+	if c.options.Tracer != nil {
+		var ctx context.Context
+
+		ctx, finish = c.options.Tracer.StartCall(req.Context(), info)
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	format, _ := c.formatOf(req.Context())
+
+	if resp, ok := c.cache.lookup(info, req); ok {
+		if callOpts.Response != nil {
+			*callOpts.Response = resp.HTTP
+		}
+
+		err := c.decodeResponse(format, resp.Body, jsonResponse)
+
+		event := newCallEvent(info, req, resp, 0, time.Since(start), err)
+		event.CacheHit = true
+
+		c.finishCall(finish, event)
+
+		return err
+	}
+
+	policy := c.options.RetryPolicy
+	if callOpts.RetryPolicy != nil {
+		policy = *callOpts.RetryPolicy
+	}
+
+	resp, attempts, err := c.doWithRetries(req, policy)
+
+	if resp != nil && callOpts.Response != nil {
+		*callOpts.Response = resp.HTTP
+	}
+
+	body, err := c.cache.update(info, req, resp, err)
+	if err == nil {
+		err = c.decodeResponse(format, body, jsonResponse)
+	}
+
+	if err == nil {
+		err = c.checkSchema(info, format, body, jsonResponse)
+	}
+
+	c.finishCall(finish, newCallEvent(info, req, resp, attempts, time.Since(start), err))
+
+	return err
+}
+
+// finishCall ends call span and records call metrics.
+func (c *YandexMarketClient) finishCall(finish func(CallEvent), event CallEvent) {
+	finish(event)
+
+	if c.options.Metrics != nil {
+		c.options.Metrics.RecordCall(event)
+	}
+}
+
+// decodeResponse decodes body in format, *[]byte receives body as is.
+func (c *YandexMarketClient) decodeResponse(format Format, body []byte, response interface{}) error {
+	if raw, ok := response.(*[]byte); ok {
+		*raw = body
+
+		return nil
+	}
+
+	if err := format.unmarshal(body, response); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", format, err)
+	}
+
+	return nil
+}
+
+// doWithRetries sends request until it succeeds or retry policy gives up.
+// It returns response of the last attempt and number of attempts made.
+func (c *YandexMarketClient) doWithRetries(req *http.Request, policy RetryPolicy) (*Response, int, error) {
+	campaignID, group := parseResourcePath(req.URL.Path)
+	reauthenticated := false
+
+	for attempt := 1; ; attempt++ {
+		if err := c.breakers.allow(group); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		if err := c.limiter.Wait(req.Context(), campaignID, group); err != nil {
+			c.breakers.done(group, context.Canceled)
+
+			return nil, attempt - 1, fmt.Errorf("wait for rate limiter: %w", err)
+		}
+
+		resp, err := c.doer.Do(req)
+
+		c.breakers.done(group, err)
+
+		if err == nil {
+			return resp, attempt, nil
+		}
+
+		if !reauthenticated && errors.Is(err, ErrUnauthorized) {
+			if inv, ok := c.options.Authenticator.(invalidator); ok {
+				reauthenticated = true
+
+				inv.Invalidate()
+
+				if req, err = c.reauthenticate(req); err != nil {
+					return resp, attempt, err
+				}
+
+				attempt--
+
+				continue
+			}
+		}
+
+		if !policy.shouldRetry(req, attempt, err) {
+			return resp, attempt, err
+		}
+
+		delay := policy.delay(attempt, err)
+
+		c.options.Logger.Debug("retrying request",
+			zap.String("method", req.Method),
+			zap.String("path", req.URL.Path),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return resp, attempt, fmt.Errorf("wait for retry: %w", err)
+		}
+
+		if req, err = rewindRequest(req); err != nil {
+			return resp, attempt, fmt.Errorf("rewind request body: %w", err)
+		}
+	}
+}
+
+// reauthenticate prepares request failed with 401 to be sent again with fresh credentials.
+func (c *YandexMarketClient) reauthenticate(req *http.Request) (*http.Request, error) {
+	next, err := rewindRequest(req)
 	if err != nil {
-		return fmt.Errorf("execute request: %w", err)
+		return nil, fmt.Errorf("rewind request body: %w", err)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(jsonResponse); err != nil {
-		return fmt.Errorf("unmarshal json: %w", err)
+	if err := c.options.Authenticator.Authenticate(next); err != nil {
+		return nil, fmt.Errorf("authenticate request: %w", err)
+	}
+
+	return next, nil
+}
+
+// send sends request once and wraps its response.
+func (c *YandexMarketClient) send(req *http.Request) (*Response, error) {
+	resp, err := c.options.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
 	}
 
 	defer func() {
@@ -138,5 +380,30 @@ func (c *YandexMarketClient) executeRequest(req *http.Request, jsonResponse inte
 		}
 	}()
 
-	return nil
+	campaignID, group := parseResourcePath(req.URL.Path)
+	c.quota.Record(campaignID, group, resp.Header, time.Now())
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	response := &Response{
+		HTTP: resp,
+		Body: body,
+	}
+
+	commonResponse := models.CommonResponse{}
+	format, _ := c.formatOf(req.Context())
+
+	if err := format.unmarshal(body, &commonResponse); err == nil {
+		response.Status = commonResponse.Status
+		response.Errors = commonResponse.Errors
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return response, newAPIError(resp, body, format)
+	}
+
+	return response, nil
 }
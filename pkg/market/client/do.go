@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// Do calls API endpoint not wrapped by client yet.
+// Path is relative to API endpoint, like "/v2/campaigns/1/offers", format suffix is added if missing.
+// With WithCallJSONOnly option path is sent as is and the call is made in json.
+// Body is sent as is if it is []byte and marshaled in call format otherwise, nil body sends no body.
+// Response is decoded into out unless it is nil, *[]byte out receives response body as is.
+// Do uses configured authentication, retries, rate limits, middlewares and logging,
+// and returns error if response has "ERROR" status.
+func (c *YandexMarketClient) Do(
+	ctx context.Context,
+	method, path string,
+	query url.Values,
+	body, out interface{},
+	opts ...CallOption,
+) error {
+	ctx = WithCallOptions(ctx, opts...)
+
+	format, suffix := c.formatOf(ctx)
+	if suffix != "" {
+		path = trimFormatSuffix(path)
+	}
+
+	path = "/" + strings.TrimPrefix(path, "/")
+
+	var requestBody []byte
+
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		requestBody = b
+	default:
+		var err error
+
+		requestBody, err = format.marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, requestBody)
+	if err != nil {
+		return err
+	}
+
+	var raw []byte
+
+	err = c.executeRequest(req, &raw)
+	if err != nil {
+		return err
+	}
+
+	commonResponse := models.CommonResponse{}
+	if err := format.unmarshal(raw, &commonResponse); err == nil && commonResponse.Status.IsError() {
+		return fmt.Errorf("failed to call %s %s: %w", method, path, commonResponse.Errors)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return c.decodeResponse(format, raw, out)
+}
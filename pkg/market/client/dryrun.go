@@ -0,0 +1,126 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// Batch limits of API methods.
+const (
+	MaxOfferPricesPerCall         = 2000
+	MaxHiddenOffersPerCall        = 500
+	MaxBusinessOfferPricesPerCall = 500
+)
+
+// ErrTooManyItems is returned when call sends more offers than API accepts.
+var ErrTooManyItems = errors.New("too many items in a call")
+
+// maxItems maps operation to the maximum number of offers sent in a call.
+var maxItems = map[string]int{
+	"SetOfferPrices":         MaxOfferPricesPerCall,
+	"DeleteOfferPrices":      MaxOfferPricesPerCall,
+	"HideOffers":             MaxHiddenOffersPerCall,
+	"UnhideOffers":           MaxHiddenOffersPerCall,
+	"SetBusinessOfferPrices": MaxBusinessOfferPricesPerCall,
+}
+
+// readOnlyOperations are operations sent with POST that do not modify anything.
+var readOnlyOperations = map[string]bool{
+	"GetBusinessOfferPrices": true,
+}
+
+// DryRunRequest is a request that would be sent if dry-run mode was off.
+type DryRunRequest struct {
+	CallInfo
+
+	Header http.Header
+	Body   []byte
+}
+
+// DryRunHandler receives requests skipped in dry-run mode.
+type DryRunHandler func(req DryRunRequest)
+
+// WithDryRun enables dry-run mode: mutating calls are built, validated and logged
+// but not sent, client methods return successful result instead.
+// Handler receives skipped requests, it may be nil.
+// GET calls are sent as usual.
+func WithDryRun(handler DryRunHandler) Option {
+	return func(o *Options) {
+		o.DryRun = true
+		o.DryRunHandler = handler
+	}
+}
+
+// WithCallDryRun enables or disables dry-run mode for the call, see WithDryRun.
+func WithCallDryRun(enabled bool) CallOption {
+	return func(o *CallOptions) {
+		o.DryRun = &enabled
+	}
+}
+
+// isDryRun reports whether request should be skipped in dry-run mode.
+func (c *YandexMarketClient) isDryRun(req *http.Request, callOpts CallOptions) bool {
+	if isReadOnly(req) {
+		return false
+	}
+
+	if callOpts.DryRun != nil {
+		return *callOpts.DryRun
+	}
+
+	return c.options.DryRun
+}
+
+// executeDryRun validates and logs request and returns synthetic response instead of sending it.
+func (c *YandexMarketClient) executeDryRun(info CallInfo, req *http.Request, jsonResponse interface{}) error {
+	if limit, ok := maxItems[info.Operation]; ok && info.Items > limit {
+		return fmt.Errorf("%s: %w: %d offers, max %d", info.Operation, ErrTooManyItems, info.Items, limit)
+	}
+
+	var body []byte
+
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return fmt.Errorf("read request body: %w", err)
+		}
+
+		body, err = ioutil.ReadAll(reader)
+		_ = reader.Close()
+
+		if err != nil {
+			return fmt.Errorf("read request body: %w", err)
+		}
+	}
+
+	c.options.Logger.Info("yandex market dry run",
+		zap.String("operation", info.Operation),
+		zap.String("method", req.Method),
+		zap.String("path", req.URL.Path),
+		zap.Int("items", info.Items),
+		zap.String("request_body", truncateBody(body, DefaultMaxLoggedBodySize)),
+	)
+
+	if c.options.DryRunHandler != nil {
+		c.options.DryRunHandler(DryRunRequest{
+			CallInfo: info,
+			Header:   req.Header.Clone(),
+			Body:     body,
+		})
+	}
+
+	format, _ := c.formatOf(req.Context())
+
+	response, err := format.marshal(models.CommonResponse{Status: models.StatusOk})
+	if err != nil {
+		return fmt.Errorf("marshal response: %w", err)
+	}
+
+	return c.decodeResponse(format, response, jsonResponse)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// StatusEnhanceYourCalm is a non-standard status yandex market returns when call quota is exceeded.
+const StatusEnhanceYourCalm = 420
+
+// maxErrorBodySize limits the size of raw body snippet stored in APIError.
+const maxErrorBodySize = 1024
+
+// requestIDHeaders are headers that may contain identifier of request on yandex market side.
+var requestIDHeaders = []string{"X-Request-Id", "X-Market-Request-Id"}
+
+var (
+	// ErrBadRequest is returned when API responds with 400 status.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is returned when API responds with 401 status.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when API responds with 403 status.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound is returned when API responds with 404 status.
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited is returned when API responds with 420 or 429 status.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError is returned when API responds with 5xx status.
+	ErrServerError = errors.New("server error")
+)
+
+// APIError describes API call that finished with non-successful HTTP status.
+// Use errors.Is with Err* sentinels to check the kind of failure.
+type APIError struct {
+	StatusCode int
+	Errors     models.CommonErrors
+	Body       string
+	Method     string
+	Path       string
+	RequestID  string
+	// RetryAfter is a delay requested by API with Retry-After header.
+	RetryAfter time.Duration
+}
+
+// newAPIError builds APIError from response and its already read body encoded in format.
+func newAPIError(resp *http.Response, body []byte, format Format) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	if len(body) > maxErrorBodySize {
+		apiErr.Body = truncateUTF8(apiErr.Body, maxErrorBodySize)
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+
+			break
+		}
+	}
+
+	commonResponse := models.CommonResponse{}
+	if err := format.unmarshal(body, &commonResponse); err == nil {
+		apiErr.Errors = commonResponse.Errors
+	}
+
+	return apiErr
+}
+
+// truncateUTF8 cuts text to at most maxSize bytes without splitting multi-byte runes.
+func truncateUTF8(text string, maxSize int) string {
+	if len(text) <= maxSize {
+		return text
+	}
+
+	cut := maxSize
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+
+	return text[:cut]
+}
+
+// Error implements error interface.
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "yandex market api: %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+
+	switch {
+	case len(e.Errors) > 0:
+		fmt.Fprintf(&b, ": %s", e.Errors.Error())
+	case e.Body != "":
+		fmt.Fprintf(&b, ": %s", e.Body)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id: %s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// Is reports whether error matches one of Err* sentinels.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == StatusEnhanceYourCalm || e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// Unwrap returns errors reported by API, so they can be extracted with errors.As.
+func (e *APIError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e.Errors
+}
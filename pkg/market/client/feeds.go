@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// FeedsService manages campaign feeds.
+type FeedsService struct {
+	client     *YandexMarketClient
+	campaignID int64
+}
+
+// List returns list of feeds placed in Yandex.Market for the campaign.
+func (s *FeedsService) List(ctx context.Context, opts ...CallOption) ([]models.Feed, error) {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "ListFeeds", 0)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet,
+		fmt.Sprintf("/v2/campaigns/%d/feeds", s.campaignID), url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	feedResponse := &models.FeedResponse{}
+
+	err = s.client.executeRequest(req, feedResponse)
+
+	return feedResponse.Feeds, err
+}
+
+// Refresh tells Yandex.Market that feed was refreshed.
+// After this, Yandex.Market starts updating feed data.
+func (s *FeedsService) Refresh(ctx context.Context, feedID int64, opts ...CallOption) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "RefreshFeed", 0)
+
+	req, err := s.client.newRequest(ctx,
+		http.MethodPost,
+		fmt.Sprintf("/campaigns/%d/feeds/%d/refresh", s.campaignID, feedID), url.Values{}, nil)
+	if err != nil {
+		return err
+	}
+
+	refreshResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, refreshResponse)
+	if err != nil {
+		return err
+	}
+
+	if refreshResponse.Status.IsError() {
+		return fmt.Errorf("failed to refresh feed: %w", refreshResponse.Errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+)
+
+// Format is a format of request and response bodies.
+type Format string
+
+const (
+	// FormatJSON is a default format.
+	FormatJSON Format = "json"
+	// FormatXML encodes requests as xml with <request> root element.
+	FormatXML Format = "xml"
+)
+
+// xmlRequestRoot is a name of root element of xml request bodies.
+const xmlRequestRoot = "request"
+
+// WithFormat sets format of request and response bodies.
+func WithFormat(format Format) Option {
+	return func(o *Options) {
+		o.Format = format
+	}
+}
+
+// WithCallJSONOnly sends the call in json with resource path as is, without format suffix,
+// whatever client format is. Use it with Do for endpoints documented without suffix, like business ones.
+func WithCallJSONOnly() CallOption {
+	return func(o *CallOptions) {
+		o.JSONOnly = true
+	}
+}
+
+// withJSONOnly marks calls of endpoints documented without format suffix and accepting only json,
+// like business endpoints. They are sent in json whatever client format is.
+func withJSONOnly(ctx context.Context) context.Context {
+	return WithCallOptions(ctx, WithCallJSONOnly())
+}
+
+// formatOf returns format of call made with ctx and suffix appended to its resource path.
+func (c *YandexMarketClient) formatOf(ctx context.Context) (format Format, suffix string) {
+	if callOptionsFrom(ctx).JSONOnly {
+		return FormatJSON, ""
+	}
+
+	return c.options.Format, c.options.Format.suffix()
+}
+
+// suffix is appended to resource path.
+func (f Format) suffix() string {
+	return "." + string(f)
+}
+
+// trimFormatSuffix removes suffix of any format from resource path.
+func trimFormatSuffix(path string) string {
+	for _, format := range []Format{FormatJSON, FormatXML} {
+		if strings.HasSuffix(path, format.suffix()) {
+			return strings.TrimSuffix(path, format.suffix())
+		}
+	}
+
+	return path
+}
+
+func (f Format) contentType() string {
+	if f == FormatXML {
+		return "application/xml"
+	}
+
+	return "application/json"
+}
+
+func (f Format) marshal(v interface{}) ([]byte, error) {
+	if f != FormatXML {
+		return json.Marshal(v)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+
+	if err := xml.NewEncoder(&buf).EncodeElement(v, xml.StartElement{Name: xml.Name{Local: xmlRequestRoot}}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (f Format) unmarshal(data []byte, v interface{}) error {
+	if f == FormatXML {
+		return xml.Unmarshal(data, v)
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// HiddenOffersService manages hidden offers of campaign.
+type HiddenOffersService struct {
+	client     *YandexMarketClient
+	campaignID int64
+}
+
+// Hide hides offers.
+// Can hide up too 500 offers per call.
+func (s *HiddenOffersService) Hide(
+	ctx context.Context,
+	offersToHide []models.HiddenOffer,
+	opts ...CallOption,
+) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "HideOffers", len(offersToHide))
+
+	requestModel := models.OfferHideRequest{HiddenOffers: offersToHide}
+
+	requestBody, err := s.client.options.Format.marshal(requestModel)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("v2/campaigns/%d/hidden-offers", s.campaignID),
+		url.Values{},
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	hideOffersResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, hideOffersResponse)
+	if err != nil {
+		return err
+	}
+
+	if hideOffersResponse.Status.IsError() {
+		return fmt.Errorf("failed to hide offers: %w", hideOffersResponse.Errors)
+	}
+
+	return nil
+}
+
+// List returns list of hidden offers.
+// Call options are passed with WithCallOptions.
+func (s *HiddenOffersService) List(
+	ctx context.Context,
+	opts ...models.GetHiddenOffersOption,
+) (models.GetHiddenOfferResult, error) {
+	ctx = withOperation(ctx, "GetHiddenOffers", 0)
+
+	o := models.GetHiddenOffersOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	query := o.ToQueryArgs()
+
+	req, err := s.client.newRequest(ctx, http.MethodGet,
+		fmt.Sprintf("/v2/campaigns/%d/hidden-offers", s.campaignID),
+		query,
+		nil)
+	if err != nil {
+		return models.GetHiddenOfferResult{}, err
+	}
+
+	getHiddenOffersResponse := &models.GetHiddenOfferResponse{}
+
+	err = s.client.executeRequest(req, getHiddenOffersResponse)
+	if err != nil {
+		return models.GetHiddenOfferResult{}, err
+	}
+
+	if getHiddenOffersResponse.Status.IsError() {
+		return models.GetHiddenOfferResult{}, fmt.Errorf("failed to hide offers: %w", getHiddenOffersResponse.Errors)
+	}
+
+	return getHiddenOffersResponse.Result, nil
+}
+
+// Unhide unhides offers.
+func (s *HiddenOffersService) Unhide(
+	ctx context.Context,
+	offersToUnhide []models.OfferToUnhide,
+	opts ...CallOption,
+) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "UnhideOffers", len(offersToUnhide))
+
+	requestModel := models.OfferUnhideRequest{HiddenOffers: offersToUnhide}
+	requestBody, err := s.client.options.Format.marshal(requestModel)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete,
+		fmt.Sprintf("/v2/campaigns/%d/hidden-offers", s.campaignID),
+		url.Values{},
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	unhideResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, unhideResponse)
+	if err != nil {
+		return err
+	}
+
+	if unhideResponse.Status.IsError() {
+		return fmt.Errorf("failed to unhide offers: %w", unhideResponse.Errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RequestLogLevel describes how verbose request logging is.
+type RequestLogLevel int
+
+const (
+	// RequestLogOff disables request logging.
+	RequestLogOff RequestLogLevel = iota
+	// RequestLogInfo logs method, path, status and latency of every request with info level.
+	RequestLogInfo
+	// RequestLogDebug additionally logs query, headers and body sizes with debug level.
+	RequestLogDebug
+	// RequestLogBodies additionally logs truncated request and response bodies with debug level.
+	RequestLogBodies
+)
+
+// DefaultMaxLoggedBodySize is a default limit of logged body size.
+const DefaultMaxLoggedBodySize = 4096
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are headers never logged as is.
+var sensitiveHeaders = map[string]bool{
+	"Authorization": true,
+	"Api-Key":       true,
+}
+
+// sensitiveQueryArgs are query args never logged as is.
+var sensitiveQueryArgs = map[string]bool{
+	"oauth_token":   true,
+	"access_token":  true,
+	"refresh_token": true,
+	"api_key":       true,
+}
+
+// secretPattern matches oauth tokens embedded in text.
+var secretPattern = regexp.MustCompile(
+	`(?i)(oauth_token=|"?(?:access_token|refresh_token|api_key)"?\s*[:=]\s*"?|bearer\s+|api-key:\s*)[^\s,"&]+`)
+
+// RequestLogging configures request logging.
+type RequestLogging struct {
+	Level RequestLogLevel
+	// MaxBodySize limits size of logged bodies, DefaultMaxLoggedBodySize is used if zero.
+	MaxBodySize int
+}
+
+// WithRequestLogging configures logging of every request with client logger.
+// Authorization headers and oauth tokens are redacted.
+func WithRequestLogging(logging RequestLogging) Option {
+	return func(o *Options) {
+		o.RequestLogging = logging
+	}
+}
+
+// loggingMiddleware returns middleware logging requests according to configuration.
+func loggingMiddleware(logger *zap.Logger, logging RequestLogging) Middleware {
+	if logging.MaxBodySize <= 0 {
+		logging.MaxBodySize = DefaultMaxLoggedBodySize
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*Response, error) {
+			start := time.Now()
+
+			var requestBody []byte
+			if logging.Level >= RequestLogDebug && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					requestBody, _ = ioutil.ReadAll(body)
+					_ = body.Close()
+				}
+			}
+
+			resp, err := next.Do(req)
+
+			fields := []zap.Field{
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.Duration("latency", time.Since(start)),
+			}
+
+			if resp != nil {
+				fields = append(fields, zap.Int("status", resp.HTTP.StatusCode))
+
+				if resp.Status != "" {
+					fields = append(fields, zap.String("api_status", string(resp.Status)))
+				}
+			}
+
+			if err != nil {
+				fields = append(fields, zap.Error(err))
+			}
+
+			if logging.Level < RequestLogDebug {
+				logger.Info("yandex market request", fields...)
+
+				return resp, err
+			}
+
+			fields = append(fields,
+				zap.String("query", redactQuery(req.URL.Query())),
+				zap.Any("request_headers", redactHeaders(req.Header)),
+				zap.Int("request_size", len(requestBody)),
+			)
+
+			if resp != nil {
+				fields = append(fields,
+					zap.Any("response_headers", redactHeaders(resp.HTTP.Header)),
+					zap.Int("response_size", len(resp.Body)),
+				)
+			}
+
+			if logging.Level >= RequestLogBodies {
+				fields = append(fields, zap.String("request_body", truncateBody(requestBody, logging.MaxBodySize)))
+
+				if resp != nil {
+					fields = append(fields, zap.String("response_body", truncateBody(resp.Body, logging.MaxBodySize)))
+				}
+			}
+
+			logger.Debug("yandex market request", fields...)
+
+			return resp, err
+		})
+	}
+}
+
+// redactHeaders returns copy of headers with sensitive values redacted.
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = redacted
+
+			continue
+		}
+
+		result[name] = redactSecrets(strings.Join(values, ", "))
+	}
+
+	return result
+}
+
+// redactQuery encodes query with sensitive args redacted.
+func redactQuery(query url.Values) string {
+	redactedQuery := url.Values{}
+
+	for name, values := range query {
+		if sensitiveQueryArgs[strings.ToLower(name)] {
+			redactedQuery[name] = []string{redacted}
+
+			continue
+		}
+
+		redactedQuery[name] = values
+	}
+
+	return redactedQuery.Encode()
+}
+
+// redactSecrets replaces oauth tokens and api keys found in text.
+func redactSecrets(text string) string {
+	return secretPattern.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// truncateBody returns body with secrets redacted, limited to maxSize bytes.
+// Body is redacted before truncation, so secrets cut by the limit are still redacted.
+func truncateBody(body []byte, maxSize int) string {
+	text := redactSecrets(string(body))
+	if len(text) > maxSize {
+		return truncateUTF8(text, maxSize) + "...(truncated)"
+	}
+
+	return text
+}
@@ -1,273 +1,133 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
-	"net/url"
-	"strings"
 
 	"github.com/KazanExpress/yandex-market/pkg/market/models"
 )
 
 // ListFeeds returns list of feeds placed in Yandex.Market for given campaign.
-func (c *YandexMarketClient) ListFeeds(ctx context.Context, campaignID int64) ([]models.Feed, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/campaigns/%d/feeds", campaignID), url.Values{}, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	feedResponse := &models.FeedResponse{}
-
-	err = c.executeRequest(req, feedResponse)
-
-	return feedResponse.Feeds, err
+// It is a shortcut for c.Campaign(campaignID).Feeds().List(ctx, opts...).
+func (c *YandexMarketClient) ListFeeds(
+	ctx context.Context,
+	campaignID int64,
+	opts ...CallOption,
+) ([]models.Feed, error) {
+	return c.Campaign(campaignID).Feeds().List(ctx, opts...)
 }
 
 // RefreshFeed tells Yandex.Market that feed was refreshed.
-// After this, Yandex.Market starts updating feed data.
-func (c *YandexMarketClient) RefreshFeed(ctx context.Context, campaignID, feedID int64) error {
-	req, err := c.newRequest(ctx,
-		http.MethodPost,
-		fmt.Sprintf("/campaigns/%d/feeds/%d/refresh", campaignID, feedID), url.Values{}, nil)
-	if err != nil {
-		return err
-	}
-
-	refreshResponse := &models.CommonResponse{}
-
-	err = c.executeRequest(req, refreshResponse)
-	if err != nil {
-		return err
-	}
-
-	if refreshResponse.Status.IsError() {
-		return fmt.Errorf("failed to refresh feed: %w", refreshResponse.Errors)
-	}
-
-	return nil
+// It is a shortcut for c.Campaign(campaignID).Feeds().Refresh(ctx, feedID, opts...).
+func (c *YandexMarketClient) RefreshFeed(ctx context.Context, campaignID, feedID int64, opts ...CallOption) error {
+	return c.Campaign(campaignID).Feeds().Refresh(ctx, feedID, opts...)
 }
 
 // SetOfferPrices overwrites prices from the feed.
-// In single call allowed to set or delete no more than 2000 offers.
-func (c *YandexMarketClient) SetOfferPrices(ctx context.Context, campaignID int64, offers []models.Offer) error {
-	priceRequest := models.SetPriceRequest{Offers: offers}
-	requestBody, err := json.Marshal(priceRequest)
-	if err != nil {
-		return fmt.Errorf("marshal json: %w", err)
-	}
-
-	req, err := c.newRequest(ctx, http.MethodPost,
-		fmt.Sprintf("/v2/campaigns/%d/offer-prices/updates", campaignID),
-		url.Values{},
-		bytes.NewReader(requestBody))
-	if err != nil {
-		return err
-	}
-
-	setPriceResponse := &models.CommonResponse{}
-
-	err = c.executeRequest(req, setPriceResponse)
-	if err != nil {
-		return err
-	}
-
-	if setPriceResponse.Status.IsError() {
-		return fmt.Errorf("failed to set prices: %w", setPriceResponse.Errors)
-	}
+// It is a shortcut for c.Campaign(campaignID).Prices().Set(ctx, offers, opts...).
+func (c *YandexMarketClient) SetOfferPrices(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.Offer,
+	opts ...CallOption,
+) error {
+	return c.Campaign(campaignID).Prices().Set(ctx, offers, opts...)
+}
 
-	return nil
+// SetOfferPricesBatched sets prices of any number of offers splitting them into chunks.
+// It is a shortcut for c.Campaign(campaignID).Prices().SetBatched(ctx, offers, opts...).
+func (c *YandexMarketClient) SetOfferPricesBatched(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.Offer,
+	opts ...BatchOption,
+) (BatchResult, error) {
+	return c.Campaign(campaignID).Prices().SetBatched(ctx, offers, opts...)
 }
 
 // GetOfferPrices returns prices set with SetOfferPrices.
+// It is a shortcut for c.Campaign(campaignID).Prices().List(ctx, opts...).
 func (c *YandexMarketClient) GetOfferPrices(ctx context.Context,
 	campaignID int64,
 	opts ...models.GetOfferPricesOption,
 ) ([]models.GetPriceOfferModel, error) {
-	o := models.GetOfferPricesOptions{}
-
-	for _, opt := range opts {
-		opt(&o)
-	}
-
-	query := o.ToQueryArgs()
-
-	req, err := c.newRequest(ctx, http.MethodGet,
-		fmt.Sprintf("/v2/campaigns/%d/offer-prices", campaignID), query, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	getPriceResponse := &models.GetPricesResponse{}
-
-	err = c.executeRequest(req, getPriceResponse)
-	if err != nil {
-		return nil, err
-	}
-
-	if getPriceResponse.Status.IsError() {
-		return nil, fmt.Errorf("failed to get prices: %w", getPriceResponse.Errors)
-	}
-
-	return getPriceResponse.Result.Offers, nil
+	return c.Campaign(campaignID).Prices().List(ctx, opts...)
 }
 
 // DeleteAllOffersPrices deletes all prices set with API.
-// After deleting prices from the feed will be used.
-func (c *YandexMarketClient) DeleteAllOffersPrices(ctx context.Context, campaignID int64) error {
-	req, err := c.newRequest(ctx, http.MethodPost,
-		fmt.Sprintf("/v2/campaigns/%d/offer-prices/removals", campaignID),
-		url.Values{},
-		strings.NewReader(`{"removeAll": true}`))
-	if err != nil {
-		return err
-	}
-
-	deletePricesResponse := &models.CommonResponse{}
+// It is a shortcut for c.Campaign(campaignID).Prices().DeleteAll(ctx, opts...).
+func (c *YandexMarketClient) DeleteAllOffersPrices(ctx context.Context, campaignID int64, opts ...CallOption) error {
+	return c.Campaign(campaignID).Prices().DeleteAll(ctx, opts...)
+}
 
-	err = c.executeRequest(req, deletePricesResponse)
-	if err != nil {
-		return err
-	}
+// DeleteOfferPrices deletes prices of offers set with API splitting offers into chunks.
+// It is a shortcut for c.Campaign(campaignID).Prices().Delete(ctx, offers, opts...).
+func (c *YandexMarketClient) DeleteOfferPrices(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.OfferRef,
+	opts ...BatchOption,
+) (BatchResult, error) {
+	return c.Campaign(campaignID).Prices().Delete(ctx, offers, opts...)
+}
 
-	if deletePricesResponse.Status.IsError() {
-		return fmt.Errorf("failed to delete prices: %w", deletePricesResponse.Errors)
-	}
+// SetBusinessOfferPrices sets prices of offers in all campaigns of the business.
+// It is a shortcut for c.Business(businessID).Prices().Set(ctx, offers, opts...).
+func (c *YandexMarketClient) SetBusinessOfferPrices(
+	ctx context.Context,
+	businessID int64,
+	offers []models.BusinessOfferPrice,
+	opts ...CallOption,
+) error {
+	return c.Business(businessID).Prices().Set(ctx, offers, opts...)
+}
 
-	return nil
+// GetBusinessOfferPrices returns prices of business offers.
+// It is a shortcut for c.Business(businessID).Prices().List(ctx, opts...).
+func (c *YandexMarketClient) GetBusinessOfferPrices(
+	ctx context.Context,
+	businessID int64,
+	opts ...models.GetBusinessPricesOption,
+) (models.BusinessPricesResult, error) {
+	return c.Business(businessID).Prices().List(ctx, opts...)
 }
 
 // HideOffers hides offers.
-// Can hide up too 500 offers per call.
+// It is a shortcut for c.Campaign(campaignID).HiddenOffers().Hide(ctx, offersToHide, opts...).
 func (c *YandexMarketClient) HideOffers(
 	ctx context.Context,
 	campaignID int64,
 	offersToHide []models.HiddenOffer,
+	opts ...CallOption,
 ) error {
-	requestModel := models.OfferHideRequest{HiddenOffers: offersToHide}
-
-	requestBody, err := json.Marshal(requestModel)
-	if err != nil {
-		return fmt.Errorf("json marshal: %w", err)
-	}
-
-	req, err := c.newRequest(ctx, http.MethodPost,
-		fmt.Sprintf("v2/campaigns/%d/hidden-offers", campaignID),
-		url.Values{},
-		bytes.NewReader(requestBody))
-	if err != nil {
-		return err
-	}
-
-	hideOffersResponse := &models.CommonResponse{}
-
-	err = c.executeRequest(req, hideOffersResponse)
-	if err != nil {
-		return err
-	}
-
-	if hideOffersResponse.Status.IsError() {
-		return fmt.Errorf("failed to hide offers: %w", hideOffersResponse.Errors)
-	}
-
-	return nil
+	return c.Campaign(campaignID).HiddenOffers().Hide(ctx, offersToHide, opts...)
 }
 
 // GetHiddenOffers returns list of hidden offers for campaign.
+// It is a shortcut for c.Campaign(campaignID).HiddenOffers().List(ctx, opts...).
 func (c *YandexMarketClient) GetHiddenOffers(ctx context.Context,
 	campaignID int64,
 	opts ...models.GetHiddenOffersOption,
 ) (models.GetHiddenOfferResult, error) {
-	o := models.GetHiddenOffersOptions{}
-	for _, opt := range opts {
-		opt(&o)
-	}
-
-	query := o.ToQueryArgs()
-
-	req, err := c.newRequest(ctx, http.MethodGet,
-		fmt.Sprintf("/v2/campaigns/%d/hidden-offers", campaignID),
-		query,
-		nil)
-	if err != nil {
-		return models.GetHiddenOfferResult{}, err
-	}
-
-	getHiddenOffersResponse := &models.GetHiddenOfferResponse{}
-
-	err = c.executeRequest(req, getHiddenOffersResponse)
-	if err != nil {
-		return models.GetHiddenOfferResult{}, err
-	}
-
-	if getHiddenOffersResponse.Status.IsError() {
-		return models.GetHiddenOfferResult{}, fmt.Errorf("failed to hide offers: %w", getHiddenOffersResponse.Errors)
-	}
-
-	return getHiddenOffersResponse.Result, nil
+	return c.Campaign(campaignID).HiddenOffers().List(ctx, opts...)
 }
 
 // UnhideOffers unhides offers.
+// It is a shortcut for c.Campaign(campaignID).HiddenOffers().Unhide(ctx, offersToUnhide, opts...).
 func (c *YandexMarketClient) UnhideOffers(
 	ctx context.Context,
 	campaignID int64,
 	offersToUnhide []models.OfferToUnhide,
+	opts ...CallOption,
 ) error {
-	requestModel := models.OfferUnhideRequest{HiddenOffers: offersToUnhide}
-	requestBody, err := json.Marshal(requestModel)
-	if err != nil {
-		return fmt.Errorf("json marshal: %w", err)
-	}
-
-	req, err := c.newRequest(ctx, http.MethodDelete,
-		fmt.Sprintf("/v2/campaigns/%d/hidden-offers", campaignID),
-		url.Values{},
-		bytes.NewReader(requestBody))
-	if err != nil {
-		return err
-	}
-
-	unhideResponse := &models.CommonResponse{}
-
-	err = c.executeRequest(req, unhideResponse)
-	if err != nil {
-		return err
-	}
-
-	if unhideResponse.Status.IsError() {
-		return fmt.Errorf("failed to unhide offers: %w", unhideResponse.Errors)
-	}
-
-	return nil
+	return c.Campaign(campaignID).HiddenOffers().Unhide(ctx, offersToUnhide, opts...)
 }
 
 // ExploreOffers returns all offers that satisfy passed options.
+// It is a shortcut for c.Campaign(campaignID).Offers().Explore(ctx, opts...).
 func (c *YandexMarketClient) ExploreOffers(
 	ctx context.Context,
 	campaignID int64,
 	opts ...models.ExploreOption,
 ) (models.ExploreOffersResponse, error) {
-	o := models.ExploreOptions{}
-
-	for _, opt := range opts {
-		opt(&o)
-	}
-
-	query := o.ToQueryArgs()
-
-	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/campaigns/%d/offers", campaignID), query, nil)
-	if err != nil {
-		return models.ExploreOffersResponse{}, err
-	}
-
-	response := models.ExploreOffersResponse{}
-
-	err = c.executeRequest(req, &response)
-	if err != nil {
-		return models.ExploreOffersResponse{}, err
-	}
-
-	return response, nil
+	return c.Campaign(campaignID).Offers().Explore(ctx, opts...)
 }
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// CallInfo describes API call.
+type CallInfo struct {
+	// Operation is a name of client method, like "SetOfferPrices".
+	Operation  string
+	CampaignID int64
+	// BusinessID is set instead of CampaignID for business methods.
+	BusinessID int64
+	// Group is a method group, see RateGroup* constants.
+	Group  string
+	Method string
+	Path   string
+	// Items is a number of offers sent in the call.
+	Items int
+}
+
+// CallEvent describes finished API call.
+type CallEvent struct {
+	CallInfo
+
+	// HTTPStatus is a status of the last attempt, zero if no response was received.
+	HTTPStatus int
+	APIStatus  models.Status
+	APIErrors  models.CommonErrors
+	Latency    time.Duration
+	// Retries is a number of attempts made after the first one.
+	Retries       int
+	RequestBytes  int
+	ResponseBytes int
+	Err           error
+	// CacheHit is true when response was served from cache without sending request.
+	CacheHit bool
+}
+
+// MetricsRecorder receives events about API calls.
+type MetricsRecorder interface {
+	RecordCall(event CallEvent)
+}
+
+// WithMetrics configures recorder of API calls metrics.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(o *Options) {
+		o.Metrics = recorder
+	}
+}
+
+type callInfoKey struct{}
+
+// withOperation stores name of client method and number of sent offers in context.
+func withOperation(ctx context.Context, operation string, items int) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, CallInfo{Operation: operation, Items: items})
+}
+
+// newCallInfo describes call made with request.
+func newCallInfo(req *http.Request) CallInfo {
+	info, ok := req.Context().Value(callInfoKey{}).(CallInfo)
+
+	info.CampaignID, info.Group = parseResourcePath(req.URL.Path)
+
+	if isBusinessGroup(info.Group) {
+		info.BusinessID, info.CampaignID = info.CampaignID, 0
+	}
+
+	info.Method = req.Method
+	info.Path = req.URL.Path
+
+	if !ok {
+		info.Operation = req.Method + " " + info.Group
+	}
+
+	return info
+}
+
+// newCallEvent describes finished call.
+func newCallEvent(
+	info CallInfo,
+	req *http.Request,
+	resp *Response,
+	attempts int,
+	latency time.Duration,
+	err error,
+) CallEvent {
+	event := CallEvent{
+		CallInfo:     info,
+		Latency:      latency,
+		RequestBytes: int(req.ContentLength),
+		Err:          err,
+	}
+
+	if attempts > 1 {
+		event.Retries = attempts - 1
+	}
+
+	if resp != nil {
+		event.HTTPStatus = resp.HTTP.StatusCode
+		event.APIStatus = resp.Status
+		event.APIErrors = resp.Errors
+		event.ResponseBytes = len(resp.Body)
+	}
+
+	return event
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// Response is API response passed through middleware chain.
+type Response struct {
+	// HTTP is a raw response, its body is already read into Body.
+	HTTP *http.Response
+	Body []byte
+	// Status and Errors are decoded from the body, they are empty when response has no such fields.
+	Status models.Status
+	Errors models.CommonErrors
+}
+
+// Doer sends API requests.
+type Doer interface {
+	Do(req *http.Request) (*Response, error)
+}
+
+// DoerFunc is a function implementing Doer.
+type DoerFunc func(req *http.Request) (*Response, error)
+
+// Do implements Doer.
+func (f DoerFunc) Do(req *http.Request) (*Response, error) {
+	return f(req)
+}
+
+// Middleware wraps Doer to add behavior around API requests.
+// Middleware is called for every attempt of a call, including retries,
+// and receives already authorized request.
+// When API responds with non-successful status Doer returns both response and *APIError.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware appends middlewares to the chain, the first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// chainMiddlewares wraps doer with middlewares.
+func chainMiddlewares(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// OffersService searches offers of campaign.
+type OffersService struct {
+	client     *YandexMarketClient
+	campaignID int64
+}
+
+// Explore returns all offers that satisfy passed options.
+// Call options are passed with WithCallOptions.
+func (s *OffersService) Explore(
+	ctx context.Context,
+	opts ...models.ExploreOption,
+) (models.ExploreOffersResponse, error) {
+	ctx = withOperation(ctx, "ExploreOffers", 0)
+
+	o := models.ExploreOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	query := o.ToQueryArgs()
+
+	req, err := s.client.newRequest(ctx, http.MethodGet,
+		fmt.Sprintf("/v2/campaigns/%d/offers", s.campaignID), query, nil)
+	if err != nil {
+		return models.ExploreOffersResponse{}, err
+	}
+
+	response := models.ExploreOffersResponse{}
+
+	err = s.client.executeRequest(req, &response)
+	if err != nil {
+		return models.ExploreOffersResponse{}, err
+	}
+
+	return response, nil
+}
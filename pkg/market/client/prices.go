@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// PricesService manages offer prices of campaign set with API.
+type PricesService struct {
+	client     *YandexMarketClient
+	campaignID int64
+}
+
+// Set overwrites prices from the feed.
+// In single call allowed to set or delete no more than 2000 offers.
+func (s *PricesService) Set(ctx context.Context, offers []models.Offer, opts ...CallOption) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "SetOfferPrices", len(offers))
+
+	priceRequest := models.SetPriceRequest{Offers: offers}
+	requestBody, err := s.client.options.Format.marshal(priceRequest)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/v2/campaigns/%d/offer-prices/updates", s.campaignID),
+		url.Values{},
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	setPriceResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, setPriceResponse)
+	if err != nil {
+		return err
+	}
+
+	if setPriceResponse.Status.IsError() {
+		return fmt.Errorf("failed to set prices: %w", setPriceResponse.Errors)
+	}
+
+	return nil
+}
+
+// List returns prices set with Set.
+// Call options are passed with WithCallOptions.
+func (s *PricesService) List(
+	ctx context.Context,
+	opts ...models.GetOfferPricesOption,
+) ([]models.GetPriceOfferModel, error) {
+	ctx = withOperation(ctx, "GetOfferPrices", 0)
+
+	o := models.GetOfferPricesOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	query := o.ToQueryArgs()
+
+	req, err := s.client.newRequest(ctx, http.MethodGet,
+		fmt.Sprintf("/v2/campaigns/%d/offer-prices", s.campaignID), query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	getPriceResponse := &models.GetPricesResponse{}
+
+	err = s.client.executeRequest(req, getPriceResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if getPriceResponse.Status.IsError() {
+		return nil, fmt.Errorf("failed to get prices: %w", getPriceResponse.Errors)
+	}
+
+	return getPriceResponse.Result.Offers, nil
+}
+
+// DeleteAll deletes all prices set with API.
+// After deleting prices from the feed will be used.
+func (s *PricesService) DeleteAll(ctx context.Context, opts ...CallOption) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "DeleteAllOffersPrices", 0)
+
+	requestBody, err := s.client.options.Format.marshal(models.RemovePricesRequest{RemoveAll: true})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/v2/campaigns/%d/offer-prices/removals", s.campaignID),
+		url.Values{},
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	deletePricesResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, deletePricesResponse)
+	if err != nil {
+		return err
+	}
+
+	if deletePricesResponse.Status.IsError() {
+		return fmt.Errorf("failed to delete prices: %w", deletePricesResponse.Errors)
+	}
+
+	return nil
+}
+
+// Delete deletes prices of offers set with API splitting offers into chunks of MaxOfferPricesPerCall offers.
+// After deleting prices from the feed will be used for the offers.
+// Returned error describes failed chunks, see BatchResult for details.
+func (s *PricesService) Delete(
+	ctx context.Context,
+	offers []models.OfferRef,
+	opts ...BatchOption,
+) (BatchResult, error) {
+	ids := make([]string, len(offers))
+	for i := range offers {
+		ids[i] = offers[i].ID
+	}
+
+	return s.client.runBatched(ctx, s.campaignID, "DeleteOfferPrices", ids, MaxOfferPricesPerCall, opts,
+		func(ctx context.Context, campaign *CampaignClient, from, to int, opts ...CallOption) error {
+			return campaign.Prices().remove(ctx, offers[from:to], opts...)
+		})
+}
+
+// remove deletes prices of no more than MaxOfferPricesPerCall offers in a single call.
+func (s *PricesService) remove(ctx context.Context, offers []models.OfferRef, opts ...CallOption) error {
+	ctx = withOperation(WithCallOptions(ctx, opts...), "DeleteOfferPrices", len(offers))
+
+	requestBody, err := s.client.options.Format.marshal(models.RemovePricesRequest{Offers: offers})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost,
+		fmt.Sprintf("/v2/campaigns/%d/offer-prices/removals", s.campaignID),
+		url.Values{},
+		requestBody)
+	if err != nil {
+		return err
+	}
+
+	deletePricesResponse := &models.CommonResponse{}
+
+	err = s.client.executeRequest(req, deletePricesResponse)
+	if err != nil {
+		return err
+	}
+
+	if deletePricesResponse.Status.IsError() {
+		return fmt.Errorf("failed to delete prices: %w", deletePricesResponse.Errors)
+	}
+
+	return nil
+}
+
+// SetBatched sets prices of any number of offers splitting them into chunks of MaxOfferPricesPerCall offers.
+// Chunks failed with transient errors are resubmitted, failed chunks do not stop the rest.
+// Returned error describes failed chunks, see BatchResult for details.
+func (s *PricesService) SetBatched(
+	ctx context.Context,
+	offers []models.Offer,
+	opts ...BatchOption,
+) (BatchResult, error) {
+	ids := make([]string, len(offers))
+	for i := range offers {
+		ids[i] = offers[i].ID
+	}
+
+	return s.client.runBatched(ctx, s.campaignID, "SetOfferPrices", ids, MaxOfferPricesPerCall, opts,
+		func(ctx context.Context, campaign *CampaignClient, from, to int, opts ...CallOption) error {
+			return campaign.Prices().Set(ctx, offers[from:to], opts...)
+		})
+}
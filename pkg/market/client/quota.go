@@ -0,0 +1,131 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers yandex market uses to describe quota of requested resource.
+const (
+	HeaderQuotaLimit     = "X-RateLimit-Resource-Limit"
+	HeaderQuotaRemaining = "X-RateLimit-Resource-Remaining"
+	HeaderQuotaUntil     = "X-RateLimit-Resource-Until"
+)
+
+// Quota describes state of API quota for campaign and method group.
+type Quota struct {
+	Limit     int64
+	Remaining int64
+	// Until is a time quota is restored, zero if API did not report it.
+	Until time.Time
+	// UpdatedAt is a time of response quota was taken from.
+	UpdatedAt time.Time
+}
+
+// QuotaCallback is called with quota state taken from API response.
+type QuotaCallback func(campaignID int64, group string, quota Quota)
+
+// WithLowQuotaCallback configures callback called after every response that reports
+// remaining quota below threshold, threshold is a fraction of limit from 0 to 1.
+func WithLowQuotaCallback(threshold float64, callback QuotaCallback) Option {
+	return func(o *Options) {
+		o.LowQuotaThreshold = threshold
+		o.LowQuotaCallback = callback
+	}
+}
+
+// QuotaTracker keeps the latest quota state reported by API.
+type QuotaTracker struct {
+	threshold float64
+	callback  QuotaCallback
+
+	mu     sync.RWMutex
+	quotas map[rateKey]Quota
+}
+
+// NewQuotaTracker is QuotaTracker constructor.
+func NewQuotaTracker(threshold float64, callback QuotaCallback) *QuotaTracker {
+	return &QuotaTracker{
+		threshold: threshold,
+		callback:  callback,
+		quotas:    make(map[rateKey]Quota),
+	}
+}
+
+// Get returns the latest known quota for campaign and method group.
+func (t *QuotaTracker) Get(campaignID int64, group string) (Quota, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	quota, ok := t.quotas[rateKey{campaignID: campaignID, group: group}]
+
+	return quota, ok
+}
+
+// Record saves quota state from response headers.
+func (t *QuotaTracker) Record(campaignID int64, group string, header http.Header, now time.Time) {
+	quota, ok := parseQuota(header, now)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	t.quotas[rateKey{campaignID: campaignID, group: group}] = quota
+	t.mu.Unlock()
+
+	if t.callback != nil && quota.Limit > 0 && float64(quota.Remaining) < t.threshold*float64(quota.Limit) {
+		t.callback(campaignID, group, quota)
+	}
+}
+
+// Quota returns the latest quota state for campaign and method group reported by API.
+// For business groups, like RateGroupBusinessOfferPrices, campaignID is a business id.
+func (c *YandexMarketClient) Quota(campaignID int64, group string) (Quota, bool) {
+	return c.quota.Get(campaignID, group)
+}
+
+func parseQuota(header http.Header, now time.Time) (Quota, bool) {
+	remaining, ok := parseQuotaValue(header.Get(HeaderQuotaRemaining))
+	if !ok {
+		return Quota{}, false
+	}
+
+	quota := Quota{
+		Remaining: remaining,
+		UpdatedAt: now,
+	}
+
+	quota.Limit, _ = parseQuotaValue(header.Get(HeaderQuotaLimit))
+
+	if until := header.Get(HeaderQuotaUntil); until != "" {
+		if t, err := time.Parse(time.RFC3339, until); err == nil {
+			quota.Until = t
+		} else if t, err := http.ParseTime(until); err == nil {
+			quota.Until = t
+		}
+	}
+
+	return quota, true
+}
+
+// parseQuotaValue parses header value which is either a number
+// or a list like "resource=offer-prices, 10000" with the number at the end.
+func parseQuotaValue(value string) (int64, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if i := strings.LastIndexByte(value, ','); i >= 0 {
+		value = value[i+1:]
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Method groups sharing the same quota on yandex market side.
+const (
+	RateGroupFeeds        = "feeds"
+	RateGroupOfferPrices  = "offer-prices"
+	RateGroupHiddenOffers = "hidden-offers"
+	RateGroupOffers       = "offers"
+
+	// Business groups are limited per business instead of campaign.
+	RateGroupBusinessOfferPrices       = "businesses/offer-prices"
+	RateGroupBusinessOfferPriceUpdates = "businesses/offer-prices/updates"
+)
+
+// businessGroupPrefix starts names of business method groups.
+const businessGroupPrefix = "businesses/"
+
+// Rate is a number of requests allowed per period.
+type Rate struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimits maps method group to its rate.
+// Each campaign, or business for business groups, has its own quota for every group.
+type RateLimits map[string]Rate
+
+// DefaultRateLimits are quotas published in the partner API reference.
+// Override them with WithRateLimits when yandex assigns different quotas to the campaign.
+var DefaultRateLimits = RateLimits{
+	RateGroupFeeds:        {Requests: 1000, Period: time.Hour},
+	RateGroupOfferPrices:  {Requests: 10000, Period: time.Hour},
+	RateGroupHiddenOffers: {Requests: 10000, Period: time.Hour},
+	RateGroupOffers:       {Requests: 10000, Period: time.Hour},
+	// updates are limited by 10000 offers a minute, that is 20 calls of 500 offers.
+	RateGroupBusinessOfferPriceUpdates: {Requests: 20, Period: time.Minute},
+	RateGroupBusinessOfferPrices:       {Requests: 100, Period: time.Minute},
+}
+
+// WithRateLimits configures client-side rate limiting.
+// Calls of groups missing in limits are not limited, nil disables rate limiting at all.
+func WithRateLimits(limits RateLimits) Option {
+	return func(o *Options) {
+		o.RateLimits = limits
+	}
+}
+
+// rateLimiter is a token bucket limiter keyed by campaign and method group.
+type rateLimiter struct {
+	limits RateLimits
+
+	mu      sync.Mutex
+	buckets map[rateKey]*tokenBucket
+}
+
+type rateKey struct {
+	campaignID int64
+	group      string
+}
+
+type tokenBucket struct {
+	tokens   float64
+	capacity float64
+	// perToken is a time needed to refill one token.
+	perToken time.Duration
+	last     time.Time
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	return &rateLimiter{
+		limits:  limits,
+		buckets: make(map[rateKey]*tokenBucket),
+	}
+}
+
+// Wait blocks until request of given group is allowed for campaign or context is done.
+func (l *rateLimiter) Wait(ctx context.Context, campaignID int64, group string) error {
+	if l == nil {
+		return nil
+	}
+
+	delay, ok := l.reserve(campaignID, group, time.Now())
+	if !ok || delay <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.cancel(campaignID, group)
+
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token from the bucket and returns time to wait until the token is available.
+func (l *rateLimiter) reserve(campaignID int64, group string, now time.Time) (time.Duration, bool) {
+	rate, ok := l.limits[group]
+	if !ok || rate.Requests <= 0 || rate.Period <= 0 {
+		return 0, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := rateKey{campaignID: campaignID, group: group}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{
+			tokens:   float64(rate.Requests),
+			capacity: float64(rate.Requests),
+			perToken: rate.Period / time.Duration(rate.Requests),
+			last:     now,
+		}
+		l.buckets[key] = bucket
+	}
+
+	bucket.refill(now)
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0, true
+	}
+
+	return time.Duration(-bucket.tokens * float64(bucket.perToken)), true
+}
+
+// cancel returns reserved token back to the bucket.
+func (l *rateLimiter) cancel(campaignID int64, group string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[rateKey{campaignID: campaignID, group: group}]
+	if ok && bucket.tokens < bucket.capacity {
+		bucket.tokens++
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.last = now
+
+	if b.perToken <= 0 {
+		b.tokens = b.capacity
+
+		return
+	}
+
+	b.tokens += float64(elapsed) / float64(b.perToken)
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+}
+
+// parseResourcePath extracts campaign id and method group from request path
+// like /v2/campaigns/{campaignID}/{group}/....
+// For business paths like /businesses/{businessID}/{resource}/... it returns business id
+// and group made of "businesses/" and the rest of path, see isBusinessGroup.
+func parseResourcePath(reqPath string) (ownerID int64, group string) {
+	parts := strings.Split(strings.Trim(reqPath, "/"), "/")
+
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] != "campaigns" && parts[i] != "businesses" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(parts[i+1], 10, 64)
+		if err != nil {
+			return 0, ""
+		}
+
+		rest := parts[i+2:]
+		if len(rest) == 0 {
+			return id, ""
+		}
+
+		if ext := strings.LastIndexByte(rest[len(rest)-1], '.'); ext >= 0 {
+			rest[len(rest)-1] = rest[len(rest)-1][:ext]
+		}
+
+		if parts[i] == "businesses" {
+			return id, businessGroupPrefix + strings.Join(rest, "/")
+		}
+
+		return id, rest[0]
+	}
+
+	return 0, ""
+}
+
+// isBusinessGroup reports whether group is limited per business instead of campaign.
+func isBusinessGroup(group string) bool {
+	return strings.HasPrefix(group, businessGroupPrefix)
+}
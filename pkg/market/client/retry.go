@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy is a retry policy used when no other is configured.
+// It retries GET calls up to 3 times, mutating calls are not retried.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// NoRetries is a retry policy that disables retries.
+var NoRetries = RetryPolicy{MaxAttempts: 1}
+
+// RetryPolicy describes how calls failed with transient errors are retried.
+// Transient errors are network errors and 420, 429 and 5xx responses.
+type RetryPolicy struct {
+	// MaxAttempts is a total number of attempts including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+	// BaseDelay is a delay before the first retry, it is doubled on every next retry.
+	BaseDelay time.Duration
+	// MaxDelay caps delay between attempts, including delay requested with Retry-After header.
+	MaxDelay time.Duration
+	// RetryNonIdempotent enables retries of POST and DELETE calls,
+	// calls with idempotency key and read-only POST calls, like GetBusinessOfferPrices, are retried regardless of it.
+	RetryNonIdempotent bool
+}
+
+// shouldRetry reports whether request failed with err should be attempted again.
+func (p RetryPolicy) shouldRetry(req *http.Request, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return false
+	}
+
+	if !p.RetryNonIdempotent && !isReadOnly(req) && req.Header.Get(IdempotencyKeyHeader) == "" {
+		return false
+	}
+
+	return isTransient(err)
+}
+
+// isTransient reports whether call failed with err may succeed if attempted again.
+func isTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return errors.Is(apiErr, ErrRateLimited) || errors.Is(apiErr, ErrServerError)
+	}
+
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// delay returns jittered exponential delay before the next attempt.
+// Delay requested by API with Retry-After header takes precedence.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return p.capDelay(apiErr.RetryAfter)
+	}
+
+	backoff := p.BaseDelay << uint(attempt-1)
+	if p.BaseDelay > 0 && (backoff <= 0 || backoff>>uint(attempt-1) != p.BaseDelay) {
+		// shift overflowed, delay is as long as policy allows.
+		backoff = p.MaxDelay
+	}
+
+	backoff = p.capDelay(backoff)
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1)) //nolint:gosec // jitter does not need crypto rand.
+}
+
+func (p RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return d
+}
+
+// WithRetryPolicy configures retries of failed calls.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Options) {
+		o.RetryPolicy = policy
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isReadOnly reports whether request does not modify anything: it has idempotent method
+// or is a read-only operation sent with POST.
+func isReadOnly(req *http.Request) bool {
+	info, _ := req.Context().Value(callInfoKey{}).(CallInfo)
+
+	return isIdempotent(req.Method) || readOnlyOperations[info.Operation]
+}
+
+// parseRetryAfter parses Retry-After header value which is either number of seconds or HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// rewindRequest prepares request to be sent again by recreating its body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		next.Body = body
+	}
+
+	return next, nil
+}
+
+// sleepContext waits for given duration or until context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,305 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ErrSchemaDrift is returned in strict decoding fail mode when response does not match model.
+var ErrSchemaDrift = errors.New("response does not match model")
+
+// SchemaDrift describes differences between response and model it is decoded into.
+type SchemaDrift struct {
+	CallInfo
+
+	// UnknownFields are paths of response fields missing in model, like "result.offers[].price.vat".
+	UnknownFields []string
+	// MissingFields are paths of model fields missing in response, fields with omitempty are optional.
+	MissingFields []string
+}
+
+// SchemaDriftError is returned in strict decoding fail mode.
+type SchemaDriftError struct {
+	SchemaDrift
+}
+
+// Error implements error.
+func (e *SchemaDriftError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s: %s", e.Operation, ErrSchemaDrift)
+
+	if len(e.UnknownFields) > 0 {
+		fmt.Fprintf(&b, ", unknown fields: %s", strings.Join(e.UnknownFields, ", "))
+	}
+
+	if len(e.MissingFields) > 0 {
+		fmt.Fprintf(&b, ", missing fields: %s", strings.Join(e.MissingFields, ", "))
+	}
+
+	return b.String()
+}
+
+// Is reports whether target is ErrSchemaDrift.
+func (e *SchemaDriftError) Is(target error) bool {
+	return target == ErrSchemaDrift
+}
+
+// SchemaDriftCallback receives schema drifts found in strict decoding mode.
+type SchemaDriftCallback func(drift SchemaDrift)
+
+// StrictDecoding configures detection of response fields unknown to models.
+type StrictDecoding struct {
+	// ReportMissing enables reporting of model fields missing in response.
+	ReportMissing bool
+	// Fail makes calls with schema drift fail with *SchemaDriftError, useful for contract tests.
+	Fail bool
+	// Callback receives found drifts, it may be nil.
+	Callback SchemaDriftCallback
+}
+
+// WithStrictDecoding enables detection of differences between json responses and models.
+// Drifts are logged with warn level and passed to callback, calls succeed unless Fail is set.
+func WithStrictDecoding(decoding StrictDecoding) Option {
+	return func(o *Options) {
+		o.StrictDecoding = &decoding
+	}
+}
+
+// checkSchema compares response body with model it was decoded into.
+func (c *YandexMarketClient) checkSchema(info CallInfo, format Format, body []byte, response interface{}) error {
+	decoding := c.options.StrictDecoding
+	if decoding == nil || format != FormatJSON {
+		return nil
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return nil
+	}
+
+	diff := &schemaDiff{
+		unknown:       make(map[string]bool),
+		missing:       make(map[string]bool),
+		reportMissing: decoding.ReportMissing,
+	}
+	diff.walk(reflect.TypeOf(response), tree, "")
+
+	if len(diff.unknown) == 0 && len(diff.missing) == 0 {
+		return nil
+	}
+
+	drift := SchemaDrift{
+		CallInfo:      info,
+		UnknownFields: sortedKeys(diff.unknown),
+		MissingFields: sortedKeys(diff.missing),
+	}
+
+	c.options.Logger.Warn("yandex market response schema drift",
+		zap.String("operation", info.Operation),
+		zap.String("path", info.Path),
+		zap.Strings("unknown_fields", drift.UnknownFields),
+		zap.Strings("missing_fields", drift.MissingFields),
+	)
+
+	if decoding.Callback != nil {
+		decoding.Callback(drift)
+	}
+
+	if decoding.Fail {
+		return &SchemaDriftError{SchemaDrift: drift}
+	}
+
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// schemaDiff collects differences between decoded json tree and go type.
+type schemaDiff struct {
+	unknown       map[string]bool
+	missing       map[string]bool
+	reportMissing bool
+}
+
+type schemaField struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+func (d *schemaDiff) walk(t reflect.Type, value interface{}, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if value == nil || t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		d.walkStruct(t, object, path)
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+
+		for _, item := range items {
+			d.walk(t.Elem(), item, path+"[]")
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		for _, item := range object {
+			d.walk(t.Elem(), item, joinPath(path, "*"))
+		}
+	default:
+	}
+}
+
+func (d *schemaDiff) walkStruct(t reflect.Type, object map[string]interface{}, path string) {
+	fields := structFields(t)
+
+	for key, item := range object {
+		field, ok := findField(fields, key)
+		if !ok {
+			d.unknown[joinPath(path, key)] = true
+
+			continue
+		}
+
+		d.walk(field.typ, item, joinPath(path, field.name))
+	}
+
+	if !d.reportMissing {
+		return
+	}
+
+	for _, field := range fields {
+		if field.omitEmpty {
+			continue
+		}
+
+		if _, ok := findKey(object, field.name); !ok {
+			d.missing[joinPath(path, field.name)] = true
+		}
+	}
+}
+
+// structFields returns json fields of struct type, fields of embedded structs included.
+func structFields(t reflect.Type) []schemaField {
+	var fields []schemaField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx:]
+		}
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, structFields(embedded)...)
+
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, schemaField{
+			name:      name,
+			typ:       f.Type,
+			omitEmpty: strings.Contains(opts, ",omitempty"),
+		})
+	}
+
+	return fields
+}
+
+// findField finds field matching json key, matching is case insensitive like in encoding/json.
+func findField(fields []schemaField, key string) (schemaField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+
+	return schemaField{}, false
+}
+
+func findKey(object map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
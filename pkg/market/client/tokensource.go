@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenEndpoint is a yandex oauth token endpoint.
+const DefaultTokenEndpoint = "https://oauth.yandex.ru/token"
+
+// tokenExpiryDelta is a time before expiry when token is considered expired,
+// so it is not used in requests that reach API after the expiry.
+const tokenExpiryDelta = 30 * time.Second
+
+// ErrNoRefreshToken is returned when refresh token source has no refresh token to use.
+var ErrNoRefreshToken = errors.New("no refresh token")
+
+// Token is an oauth access token.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	// TokenType is an authorization scheme, "Bearer" is used if empty.
+	TokenType string
+	// Expiry is a time token expires, zero means token never expires.
+	Expiry time.Time
+}
+
+// Valid reports whether token is set and is not expired.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource returns oauth tokens.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// StaticTokenSource returns token source that always returns the same token.
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token(context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// RefreshTokenSource obtains new access tokens with refresh token grant.
+// Refresh token is rotated when token endpoint returns a new one.
+type RefreshTokenSource struct {
+	Endpoint     string
+	ClientID     string
+	ClientSecret string
+	Client       *http.Client
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+// NewRefreshTokenSource is RefreshTokenSource constructor that uses DefaultTokenEndpoint.
+func NewRefreshTokenSource(clientID, clientSecret, refreshToken string) *RefreshTokenSource {
+	return &RefreshTokenSource{
+		Endpoint:     DefaultTokenEndpoint,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Client:       http.DefaultClient,
+		refreshToken: refreshToken,
+	}
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Token implements TokenSource, every call requests a new token from token endpoint.
+func (s *RefreshTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", s.refreshToken)
+	form.Set("client_id", s.ClientID)
+	form.Set("client_secret", s.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute token request: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read token response: %w", err)
+	}
+
+	tokenResp := tokenResponse{}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("unmarshal token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("refresh token: %d %s: %s",
+			resp.StatusCode, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	if tokenResp.RefreshToken != "" {
+		s.refreshToken = tokenResp.RefreshToken
+	}
+
+	token := &Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: s.refreshToken,
+		TokenType:    tokenResp.TokenType,
+	}
+
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// CachedTokenSource caches token of underlying source until it expires.
+type CachedTokenSource struct {
+	source TokenSource
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewCachedTokenSource is CachedTokenSource constructor.
+func NewCachedTokenSource(source TokenSource) *CachedTokenSource {
+	return &CachedTokenSource{source: source}
+}
+
+// Token implements TokenSource.
+func (s *CachedTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = token
+
+	return token, nil
+}
+
+// Invalidate drops cached token, so the next call of Token requests a new one.
+func (s *CachedTokenSource) Invalidate() {
+	s.mu.Lock()
+	s.token = nil
+	s.mu.Unlock()
+}
+
+// invalidator is implemented by authenticators able to drop cached credentials.
+type invalidator interface {
+	Invalidate()
+}
+
+// TokenSourceAuthenticator authorizes requests with tokens from token source.
+type TokenSourceAuthenticator struct {
+	Source TokenSource
+}
+
+// Authenticate implements Authenticator.
+func (a TokenSourceAuthenticator) Authenticate(req *http.Request) error {
+	token, err := a.Source.Token(req.Context())
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	scheme := "Bearer"
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, scheme) {
+		scheme = token.TokenType
+	}
+
+	req.Header.Set("authorization", scheme+" "+token.AccessToken)
+
+	return nil
+}
+
+// Invalidate drops token cached by token source.
+func (a TokenSourceAuthenticator) Invalidate() {
+	if source, ok := a.Source.(invalidator); ok {
+		source.Invalidate()
+	}
+}
+
+// WithTokenSource configures token source used to authorize requests.
+// Tokens are cached until they expire, when API responds with 401
+// token is refreshed and call is retried once.
+func WithTokenSource(source TokenSource) Option {
+	cached, ok := source.(*CachedTokenSource)
+	if !ok {
+		cached = NewCachedTokenSource(source)
+	}
+
+	return func(o *Options) {
+		o.Authenticator = TokenSourceAuthenticator{Source: cached}
+	}
+}
@@ -0,0 +1,17 @@
+package client
+
+import "context"
+
+// Tracer starts spans around API calls.
+type Tracer interface {
+	// StartCall is called before API call with context passed to client method.
+	// Returned context is used for the call, returned function is called when the call finishes.
+	StartCall(ctx context.Context, info CallInfo) (context.Context, func(CallEvent))
+}
+
+// WithTracer configures tracer of API calls.
+func WithTracer(tracer Tracer) Option {
+	return func(o *Options) {
+		o.Tracer = tracer
+	}
+}
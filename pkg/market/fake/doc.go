@@ -0,0 +1,11 @@
+// Package fake contains in-memory yandex market API server for integration tests.
+//
+// Server keeps state of campaigns, so prices set with client.SetOfferPrices are returned
+// by client.GetOfferPrices, and allows to inject errors and latency.
+// Both json and xml formats are supported:
+//
+//	server := fake.NewServer()
+//	defer server.Close()
+//
+//	c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL()))
+package fake
@@ -0,0 +1,473 @@
+package fake
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// routePattern matches paths like /v2/campaigns/{campaignID}/{resource}{rest}.json or .xml.
+var routePattern = regexp.MustCompile(`^/(?:v2/)?campaigns/(\d+)/([a-z-]+)(/[a-z0-9/-]*)?\.(?:json|xml)$`)
+
+// businessPricesPattern matches paths like /businesses/{businessID}/offer-prices{/updates},
+// business endpoints have no format suffix and accept only json.
+var businessPricesPattern = regexp.MustCompile(`^/businesses/(\d+)/offer-prices(/updates)?$`)
+
+var refreshPattern = regexp.MustCompile(`^/(\d+)/refresh$`)
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Clone(r.Context()))
+	fault := s.takeFault(r)
+	latency := s.latency
+	s.mu.Unlock()
+
+	if strings.HasSuffix(r.URL.Path, ".xml") {
+		w = xmlWriter{w}
+	}
+
+	if latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+
+	if fault != nil {
+		writeError(w, fault.Status, fault.Code, fault.Message)
+
+		return
+	}
+
+	if match := businessPricesPattern.FindStringSubmatch(r.URL.Path); match != nil {
+		s.handleBusinessPrices(w, r, match)
+
+		return
+	}
+
+	match := routePattern.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown resource "+r.URL.Path)
+
+		return
+	}
+
+	campaignID, _ := strconv.ParseInt(match[1], 10, 64)
+	resource, rest := match[2], match[3]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.campaign(campaignID)
+
+	switch {
+	case resource == "feeds" && rest == "" && r.Method == http.MethodGet:
+		writeBody(w, models.FeedResponse{Feeds: c.feeds})
+	case resource == "feeds" && r.Method == http.MethodPost && refreshPattern.MatchString(rest):
+		c.refreshFeed(w, refreshPattern.FindStringSubmatch(rest)[1])
+	case resource == "offer-prices" && rest == "" && r.Method == http.MethodGet:
+		c.getPrices(w, r)
+	case resource == "offer-prices" && rest == "/updates" && r.Method == http.MethodPost:
+		c.setPrices(w, r)
+	case resource == "offer-prices" && rest == "/removals" && r.Method == http.MethodPost:
+		c.removePrices(w, r)
+	case resource == "hidden-offers" && rest == "" && r.Method == http.MethodGet:
+		c.getHidden(w, r)
+	case resource == "hidden-offers" && rest == "" && r.Method == http.MethodPost:
+		c.hide(w, r)
+	case resource == "hidden-offers" && rest == "" && r.Method == http.MethodDelete:
+		c.unhide(w, r)
+	case resource == "offers" && rest == "" && r.Method == http.MethodGet:
+		c.explore(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("unknown method %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleBusinessPrices(w http.ResponseWriter, r *http.Request, match []string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("unknown method %s %s", r.Method, r.URL.Path))
+
+		return
+	}
+
+	businessID, _ := strconv.ParseInt(match[1], 10, 64)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.business(businessID)
+
+	if match[2] == "/updates" {
+		b.setPrices(w, r)
+	} else {
+		b.getPrices(w, r)
+	}
+}
+
+func (b *business) setPrices(w http.ResponseWriter, r *http.Request) {
+	request := models.SetBusinessPricesRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.Offers) > MaxBusinessOfferPricesPerCall {
+		writeLimitExceeded(w, MaxBusinessOfferPricesPerCall)
+
+		return
+	}
+
+	updatedAt := time.Now().Format(time.RFC3339)
+
+	for _, offer := range request.Offers {
+		offer.Price.UpdatedAt = updatedAt
+		b.prices[offer.OfferID] = offer
+	}
+
+	writeOK(w)
+}
+
+// getPrices lists business prices, page token is an offset of the page.
+func (b *business) getPrices(w http.ResponseWriter, r *http.Request) {
+	request := models.GetBusinessPricesRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.OfferIDs) > MaxBusinessOfferPricesPerCall {
+		writeLimitExceeded(w, MaxBusinessOfferPricesPerCall)
+
+		return
+	}
+
+	wanted := make(map[string]bool, len(request.OfferIDs))
+	for _, id := range request.OfferIDs {
+		wanted[id] = true
+	}
+
+	prices := make([]models.BusinessOfferPrice, 0, len(b.prices))
+
+	for _, price := range b.sortedPrices() {
+		if len(wanted) > 0 && !wanted[price.OfferID] {
+			continue
+		}
+
+		prices = append(prices, price)
+	}
+
+	query := r.URL.Query()
+	from, to := pageBounds(len(prices), query.Get("page_token"), query.Get("limit"), "", "")
+
+	result := models.BusinessPricesResult{Offers: prices[from:to]}
+	if to < len(prices) {
+		result.Paging.NextPageToken = strconv.Itoa(to)
+	}
+
+	writeBody(w, models.GetBusinessPricesResponse{Status: models.StatusOk, Result: result})
+}
+
+func (c *campaign) refreshFeed(w http.ResponseWriter, feedID string) {
+	id, _ := strconv.ParseInt(feedID, 10, 64)
+
+	for i := range c.feeds {
+		if c.feeds[i].ID == id {
+			c.feeds[i].Download.Status = models.StatusOk
+			writeOK(w)
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "NOT_FOUND", "feed not found")
+}
+
+func (c *campaign) setPrices(w http.ResponseWriter, r *http.Request) {
+	request := models.SetPriceRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.Offers) > MaxOfferPricesPerCall {
+		writeLimitExceeded(w, MaxOfferPricesPerCall)
+
+		return
+	}
+
+	updatedAt := time.Now().Format(time.RFC3339)
+
+	for _, offer := range request.Offers {
+		key := offerKey{feedID: offer.Feed.ID, offerID: offer.ID}
+
+		if offer.Delete {
+			delete(c.prices, key)
+
+			continue
+		}
+
+		c.prices[key] = models.GetPriceOfferModel{
+			Feed:      models.Feed{ID: offer.Feed.ID},
+			ID:        offer.ID,
+			Price:     offer.Price,
+			UpdatedAt: updatedAt,
+		}
+	}
+
+	writeOK(w)
+}
+
+func (c *campaign) getPrices(w http.ResponseWriter, r *http.Request) {
+	prices := c.sortedPrices()
+	query := r.URL.Query()
+
+	from, to := pageBounds(len(prices), query.Get("offset"), query.Get("limit"), query.Get("page"), query.Get("pageSize"))
+
+	writeBody(w, models.GetPricesResponse{
+		Status: models.StatusOk,
+		Result: models.Result{
+			Offers: prices[from:to],
+			Total:  int64(len(prices)),
+		},
+	})
+}
+
+func (c *campaign) removePrices(w http.ResponseWriter, r *http.Request) {
+	request := models.RemovePricesRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.Offers) > MaxOfferPricesPerCall {
+		writeLimitExceeded(w, MaxOfferPricesPerCall)
+
+		return
+	}
+
+	if request.RemoveAll {
+		c.prices = make(map[offerKey]models.GetPriceOfferModel)
+	}
+
+	for _, offer := range request.Offers {
+		delete(c.prices, offerKey{feedID: offer.Feed.ID, offerID: offer.ID})
+	}
+
+	writeOK(w)
+}
+
+func (c *campaign) hide(w http.ResponseWriter, r *http.Request) {
+	request := models.OfferHideRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.HiddenOffers) > MaxHiddenOffersPerCall {
+		writeLimitExceeded(w, MaxHiddenOffersPerCall)
+
+		return
+	}
+
+	for _, offer := range request.HiddenOffers {
+		c.hidden[offerKey{feedID: offer.FeedID, offerID: offer.OfferID}] = offer
+	}
+
+	writeOK(w)
+}
+
+func (c *campaign) unhide(w http.ResponseWriter, r *http.Request) {
+	request := models.OfferUnhideRequest{}
+	if !readBody(w, r, &request) {
+		return
+	}
+
+	if len(request.HiddenOffers) > MaxHiddenOffersPerCall {
+		writeLimitExceeded(w, MaxHiddenOffersPerCall)
+
+		return
+	}
+
+	for _, offer := range request.HiddenOffers {
+		delete(c.hidden, offerKey{feedID: offer.FeedID, offerID: offer.OfferID})
+	}
+
+	writeOK(w)
+}
+
+func (c *campaign) getHidden(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	feedID, _ := strconv.ParseInt(query.Get("feed_id"), 10, 64)
+	offerID := query.Get("offer_id")
+
+	hidden := make([]models.HiddenOffer, 0, len(c.hidden))
+
+	for _, offer := range c.sortedHidden() {
+		if (feedID != 0 && offer.FeedID != feedID) || (offerID != "" && offer.OfferID != offerID) {
+			continue
+		}
+
+		hidden = append(hidden, offer)
+	}
+
+	from, to := pageBounds(len(hidden),
+		query.Get("offset"), query.Get("limit"), query.Get("page_number"), query.Get("page_size"))
+
+	writeBody(w, models.GetHiddenOfferResponse{
+		Status: models.StatusOk,
+		Result: models.GetHiddenOfferResult{
+			HiddenOffers: hidden[from:to],
+			Total:        int64(len(hidden)),
+		},
+	})
+}
+
+func (c *campaign) explore(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	feedID, _ := strconv.ParseInt(query.Get("feedId"), 10, 64)
+	shopCategoryID := query.Get("shopCategoryId")
+	text := strings.ToLower(query.Get("query"))
+	matched := query.Get("matched") == "true"
+
+	offers := make([]models.OfferExploreModel, 0, len(c.offers))
+
+	for _, offer := range c.offers {
+		switch {
+		case feedID != 0 && offer.FeedID != feedID,
+			shopCategoryID != "" && offer.ShopCategoryID != shopCategoryID,
+			text != "" && !strings.Contains(strings.ToLower(offer.Name), text),
+			matched && offer.ModelID == 0:
+			continue
+		}
+
+		offers = append(offers, offer)
+	}
+
+	page, _ := strconv.ParseInt(query.Get("page"), 10, 64)
+	if page <= 0 {
+		page = 1
+	}
+
+	pageSize, _ := strconv.ParseInt(query.Get("pageSize"), 10, 64)
+	if pageSize <= 0 {
+		pageSize = int64(len(offers))
+	}
+
+	from, to := pageBounds(len(offers), "", "", strconv.FormatInt(page, 10), strconv.FormatInt(pageSize, 10))
+
+	pager := models.Pager{
+		CurrentPage: page,
+		PageSize:    pageSize,
+		Total:       int64(len(offers)),
+	}
+
+	if to > from {
+		pager.From = int64(from) + 1
+		pager.To = int64(to)
+	}
+
+	if pageSize > 0 {
+		pager.PagesCount = (pager.Total + pageSize - 1) / pageSize
+	}
+
+	writeBody(w, models.ExploreOffersResponse{Offers: offers[from:to], Pager: pager})
+}
+
+// pageBounds returns slice bounds for either offset and limit or page number and page size,
+// zero or missing limit returns all items.
+func pageBounds(total int, offsetArg, limitArg, pageArg, pageSizeArg string) (from, to int) {
+	page, _ := strconv.Atoi(pageArg)
+	pageSize, _ := strconv.Atoi(pageSizeArg)
+
+	offset, _ := strconv.Atoi(offsetArg)
+	limit, _ := strconv.Atoi(limitArg)
+
+	if page > 0 && pageSize > 0 {
+		offset = (page - 1) * pageSize
+		limit = pageSize
+	}
+
+	from = clamp(offset, 0, total)
+	to = total
+
+	if limit > 0 {
+		to = clamp(from+limit, from, total)
+	}
+
+	return from, to
+}
+
+func clamp(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	default:
+		return v
+	}
+}
+
+// xmlWriter marks response to request made in xml format.
+type xmlWriter struct {
+	http.ResponseWriter
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	var err error
+	if _, ok := w.(xmlWriter); ok {
+		err = xml.NewDecoder(r.Body).Decode(v)
+	} else {
+		err = json.NewDecoder(r.Body).Decode(v)
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid body: "+err.Error())
+
+		return false
+	}
+
+	return true
+}
+
+func writeBody(w http.ResponseWriter, v interface{}) {
+	writeResponse(w, http.StatusOK, v)
+}
+
+func writeResponse(w http.ResponseWriter, status int, v interface{}) {
+	if _, ok := w.(xmlWriter); ok {
+		w.Header().Set("Content-Type", "application/xml;charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(xml.Header))
+		_ = xml.NewEncoder(w).EncodeElement(v, xml.StartElement{Name: xml.Name{Local: "response"}})
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeOK(w http.ResponseWriter) {
+	writeBody(w, models.CommonResponse{Status: models.StatusOk})
+}
+
+func writeLimitExceeded(w http.ResponseWriter, limit int) {
+	writeError(w, http.StatusBadRequest, "LIMIT_EXCEEDED", fmt.Sprintf("no more than %d offers allowed per call", limit))
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	writeResponse(w, status, models.CommonResponse{
+		Status: models.StatusError,
+		Errors: models.CommonErrors{{Code: code, Message: message}},
+	})
+}
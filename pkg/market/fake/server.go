@@ -0,0 +1,254 @@
+package fake
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// Batch limits enforced by server.
+const (
+	MaxOfferPricesPerCall         = 2000
+	MaxHiddenOffersPerCall        = 500
+	MaxBusinessOfferPricesPerCall = 500
+)
+
+// Fault describes error server responds with instead of handling request.
+type Fault struct {
+	// Method and PathContains select requests to fail, empty values match any request.
+	Method       string
+	PathContains string
+	// Status is HTTP status of response.
+	Status int
+	// Code and Message are reported in response errors.
+	Code    string
+	Message string
+	// Count is a number of requests to fail, zero means fail all matching requests.
+	Count int
+}
+
+// Server is an in-memory yandex market API server.
+type Server struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	campaigns  map[int64]*campaign
+	businesses map[int64]*business
+	faults     []*Fault
+	latency    time.Duration
+	requests   []*http.Request
+}
+
+type offerKey struct {
+	feedID  int64
+	offerID string
+}
+
+type campaign struct {
+	feeds  []models.Feed
+	prices map[offerKey]models.GetPriceOfferModel
+	hidden map[offerKey]models.HiddenOffer
+	offers []models.OfferExploreModel
+}
+
+type business struct {
+	prices map[string]models.BusinessOfferPrice
+}
+
+// NewServer starts new server.
+func NewServer() *Server {
+	s := &Server{
+		campaigns:  make(map[int64]*campaign),
+		businesses: make(map[int64]*business),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// URL returns server URL to use with client.WithAPIEndpoint.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddFeeds adds feeds to campaign.
+func (s *Server) AddFeeds(campaignID int64, feeds ...models.Feed) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.campaign(campaignID)
+	c.feeds = append(c.feeds, feeds...)
+}
+
+// AddOffers adds offers returned by explore offers endpoint.
+func (s *Server) AddOffers(campaignID int64, offers ...models.OfferExploreModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.campaign(campaignID)
+	c.offers = append(c.offers, offers...)
+}
+
+// Prices returns prices set for campaign offers sorted by offer id.
+func (s *Server) Prices(campaignID int64) []models.GetPriceOfferModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.campaign(campaignID).sortedPrices()
+}
+
+// HiddenOffers returns hidden campaign offers sorted by offer id.
+func (s *Server) HiddenOffers(campaignID int64) []models.HiddenOffer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.campaign(campaignID).sortedHidden()
+}
+
+// BusinessPrices returns prices set for business offers sorted by offer id.
+func (s *Server) BusinessPrices(businessID int64) []models.BusinessOfferPrice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.business(businessID).sortedPrices()
+}
+
+// InjectFault makes server fail requests matching fault.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// SetLatency delays every response.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = latency
+}
+
+// Requests returns requests received by server.
+func (s *Server) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*http.Request{}, s.requests...)
+}
+
+// campaign returns campaign state creating it if needed, mu must be held.
+func (s *Server) campaign(campaignID int64) *campaign {
+	c, ok := s.campaigns[campaignID]
+	if !ok {
+		c = &campaign{
+			prices: make(map[offerKey]models.GetPriceOfferModel),
+			hidden: make(map[offerKey]models.HiddenOffer),
+		}
+		s.campaigns[campaignID] = c
+	}
+
+	return c
+}
+
+// business returns business state creating it if needed, mu must be held.
+func (s *Server) business(businessID int64) *business {
+	b, ok := s.businesses[businessID]
+	if !ok {
+		b = &business{prices: make(map[string]models.BusinessOfferPrice)}
+		s.businesses[businessID] = b
+	}
+
+	return b
+}
+
+// takeFault returns fault matching request, mu must be held.
+func (s *Server) takeFault(req *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != req.Method {
+			continue
+		}
+
+		if fault.PathContains != "" && !strings.Contains(req.URL.Path, fault.PathContains) {
+			continue
+		}
+
+		if fault.Count > 0 {
+			fault.Count--
+
+			if fault.Count == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+func (c *campaign) sortedPrices() []models.GetPriceOfferModel {
+	prices := make([]models.GetPriceOfferModel, 0, len(c.prices))
+	for _, price := range c.prices {
+		prices = append(prices, price)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		if prices[i].ID == prices[j].ID {
+			return prices[i].Feed.ID < prices[j].Feed.ID
+		}
+
+		return prices[i].ID < prices[j].ID
+	})
+
+	return prices
+}
+
+func (c *campaign) sortedHidden() []models.HiddenOffer {
+	hidden := make([]models.HiddenOffer, 0, len(c.hidden))
+	for _, offer := range c.hidden {
+		hidden = append(hidden, offer)
+	}
+
+	sort.Slice(hidden, func(i, j int) bool {
+		if hidden[i].OfferID == hidden[j].OfferID {
+			return hidden[i].FeedID < hidden[j].FeedID
+		}
+
+		return hidden[i].OfferID < hidden[j].OfferID
+	})
+
+	return hidden
+}
+
+func (b *business) sortedPrices() []models.BusinessOfferPrice {
+	prices := make([]models.BusinessOfferPrice, 0, len(b.prices))
+	for _, price := range b.prices {
+		prices = append(prices, price)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].OfferID < prices[j].OfferID
+	})
+
+	return prices
+}
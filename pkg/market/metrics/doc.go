@@ -0,0 +1,2 @@
+// Package metrics contains prometheus adapter for client.MetricsRecorder.
+package metrics
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+// DefaultNamespace is a default namespace of exposed metrics.
+const DefaultNamespace = "yandex_market"
+
+// PrometheusRecorder records API calls metrics and exposes them as prometheus collector.
+type PrometheusRecorder struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	retries       *prometheus.CounterVec
+	requestBytes  *prometheus.CounterVec
+	responseBytes *prometheus.CounterVec
+	cacheHits     *prometheus.CounterVec
+	circuitState  *prometheus.GaugeVec
+}
+
+var _ client.MetricsRecorder = (*PrometheusRecorder)(nil)
+
+var _ client.CircuitStateRecorder = (*PrometheusRecorder)(nil)
+
+var _ prometheus.Collector = (*PrometheusRecorder)(nil)
+
+// NewPrometheusRecorder is PrometheusRecorder constructor, empty namespace means DefaultNamespace.
+func NewPrometheusRecorder(namespace string) *PrometheusRecorder {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	return &PrometheusRecorder{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of API calls.",
+		}, []string{"operation", "campaign_id", "business_id", "http_status", "api_status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of API calls including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried attempts of API calls.",
+		}, []string{"operation"}),
+		requestBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_bytes_total",
+			Help:      "Size of sent request bodies.",
+		}, []string{"operation"}),
+		responseBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "response_bytes_total",
+			Help:      "Size of received response bodies.",
+		}, []string{"operation"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of API calls served from response cache.",
+		}, []string{"operation"}),
+		circuitState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_state",
+			Help:      "State of circuit breaker: 0 is closed, 1 is open, 2 is half-open.",
+		}, []string{"group"}),
+	}
+}
+
+// RecordCall implements client.MetricsRecorder.
+func (r *PrometheusRecorder) RecordCall(event client.CallEvent) {
+	httpStatus := "none"
+	if event.HTTPStatus != 0 {
+		httpStatus = strconv.Itoa(event.HTTPStatus)
+	}
+
+	apiStatus := string(event.APIStatus)
+	if apiStatus == "" {
+		apiStatus = "none"
+	}
+
+	r.requests.WithLabelValues(
+		event.Operation, idLabel(event.CampaignID), idLabel(event.BusinessID), httpStatus, apiStatus,
+	).Inc()
+	r.duration.WithLabelValues(event.Operation).Observe(event.Latency.Seconds())
+	r.retries.WithLabelValues(event.Operation).Add(float64(event.Retries))
+	r.requestBytes.WithLabelValues(event.Operation).Add(float64(event.RequestBytes))
+	r.responseBytes.WithLabelValues(event.Operation).Add(float64(event.ResponseBytes))
+
+	if event.CacheHit {
+		r.cacheHits.WithLabelValues(event.Operation).Inc()
+	}
+}
+
+// idLabel formats campaign or business id, calls not scoped by it have empty label.
+func idLabel(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
+
+// RecordCircuitState implements client.CircuitStateRecorder.
+func (r *PrometheusRecorder) RecordCircuitState(group string, state client.CircuitState) {
+	if group == "" {
+		group = "all"
+	}
+
+	r.circuitState.WithLabelValues(group).Set(float64(state))
+}
+
+// Describe implements prometheus.Collector.
+func (r *PrometheusRecorder) Describe(ch chan<- *prometheus.Desc) {
+	r.requests.Describe(ch)
+	r.duration.Describe(ch)
+	r.retries.Describe(ch)
+	r.requestBytes.Describe(ch)
+	r.responseBytes.Describe(ch)
+	r.cacheHits.Describe(ch)
+	r.circuitState.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (r *PrometheusRecorder) Collect(ch chan<- prometheus.Metric) {
+	r.requests.Collect(ch)
+	r.duration.Collect(ch)
+	r.retries.Collect(ch)
+	r.requestBytes.Collect(ch)
+	r.responseBytes.Collect(ch)
+	r.cacheHits.Collect(ch)
+	r.circuitState.Collect(ch)
+}
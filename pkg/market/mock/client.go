@@ -0,0 +1,322 @@
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+var _ client.MarketAPI = (*Client)(nil)
+
+// Call is a recorded call of Client method.
+type Call struct {
+	// Method is a name of called method, like "SetOfferPrices".
+	Method string
+	// CampaignID is a campaign id, or a business id for business methods.
+	CampaignID int64
+	// Args are the rest of method arguments except context,
+	// variadic options are passed as a single slice.
+	Args []interface{}
+}
+
+// Client is a client.MarketAPI mock.
+type Client struct {
+	ListFeedsFunc   func(ctx context.Context, campaignID int64, opts ...client.CallOption) ([]models.Feed, error)
+	RefreshFeedFunc func(ctx context.Context, campaignID, feedID int64, opts ...client.CallOption) error
+
+	SetOfferPricesFunc func(
+		ctx context.Context,
+		campaignID int64,
+		offers []models.Offer,
+		opts ...client.CallOption,
+	) error
+	SetOfferPricesBatchedFunc func(
+		ctx context.Context,
+		campaignID int64,
+		offers []models.Offer,
+		opts ...client.BatchOption,
+	) (client.BatchResult, error)
+	GetOfferPricesFunc func(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.GetOfferPricesOption,
+	) ([]models.GetPriceOfferModel, error)
+	DeleteAllOffersPricesFunc func(ctx context.Context, campaignID int64, opts ...client.CallOption) error
+	DeleteOfferPricesFunc     func(
+		ctx context.Context,
+		campaignID int64,
+		offers []models.OfferRef,
+		opts ...client.BatchOption,
+	) (client.BatchResult, error)
+
+	SetBusinessOfferPricesFunc func(
+		ctx context.Context,
+		businessID int64,
+		offers []models.BusinessOfferPrice,
+		opts ...client.CallOption,
+	) error
+	GetBusinessOfferPricesFunc func(
+		ctx context.Context,
+		businessID int64,
+		opts ...models.GetBusinessPricesOption,
+	) (models.BusinessPricesResult, error)
+
+	HideOffersFunc func(
+		ctx context.Context,
+		campaignID int64,
+		offersToHide []models.HiddenOffer,
+		opts ...client.CallOption,
+	) error
+	GetHiddenOffersFunc func(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.GetHiddenOffersOption,
+	) (models.GetHiddenOfferResult, error)
+	UnhideOffersFunc func(
+		ctx context.Context,
+		campaignID int64,
+		offersToUnhide []models.OfferToUnhide,
+		opts ...client.CallOption,
+	) error
+
+	ExploreOffersFunc func(
+		ctx context.Context,
+		campaignID int64,
+		opts ...models.ExploreOption,
+	) (models.ExploreOffersResponse, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns all recorded calls.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+// CallsTo returns recorded calls of method.
+func (c *Client) CallsTo(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+
+	for _, call := range c.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets recorded calls.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	c.calls = nil
+	c.mu.Unlock()
+}
+
+func (c *Client) record(method string, campaignID int64, args ...interface{}) {
+	c.mu.Lock()
+	c.calls = append(c.calls, Call{Method: method, CampaignID: campaignID, Args: args})
+	c.mu.Unlock()
+}
+
+// ListFeeds implements client.FeedsAPI.
+func (c *Client) ListFeeds(
+	ctx context.Context,
+	campaignID int64,
+	opts ...client.CallOption,
+) ([]models.Feed, error) {
+	c.record("ListFeeds", campaignID, opts)
+
+	if c.ListFeedsFunc == nil {
+		return nil, nil
+	}
+
+	return c.ListFeedsFunc(ctx, campaignID, opts...)
+}
+
+// RefreshFeed implements client.FeedsAPI.
+func (c *Client) RefreshFeed(ctx context.Context, campaignID, feedID int64, opts ...client.CallOption) error {
+	c.record("RefreshFeed", campaignID, feedID, opts)
+
+	if c.RefreshFeedFunc == nil {
+		return nil
+	}
+
+	return c.RefreshFeedFunc(ctx, campaignID, feedID, opts...)
+}
+
+// SetOfferPrices implements client.PricesAPI.
+func (c *Client) SetOfferPrices(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.Offer,
+	opts ...client.CallOption,
+) error {
+	c.record("SetOfferPrices", campaignID, offers, opts)
+
+	if c.SetOfferPricesFunc == nil {
+		return nil
+	}
+
+	return c.SetOfferPricesFunc(ctx, campaignID, offers, opts...)
+}
+
+// SetOfferPricesBatched implements client.PricesAPI.
+func (c *Client) SetOfferPricesBatched(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.Offer,
+	opts ...client.BatchOption,
+) (client.BatchResult, error) {
+	c.record("SetOfferPricesBatched", campaignID, offers, opts)
+
+	if c.SetOfferPricesBatchedFunc == nil {
+		return client.BatchResult{}, nil
+	}
+
+	return c.SetOfferPricesBatchedFunc(ctx, campaignID, offers, opts...)
+}
+
+// GetOfferPrices implements client.PricesAPI.
+func (c *Client) GetOfferPrices(
+	ctx context.Context,
+	campaignID int64,
+	opts ...models.GetOfferPricesOption,
+) ([]models.GetPriceOfferModel, error) {
+	c.record("GetOfferPrices", campaignID, opts)
+
+	if c.GetOfferPricesFunc == nil {
+		return nil, nil
+	}
+
+	return c.GetOfferPricesFunc(ctx, campaignID, opts...)
+}
+
+// DeleteAllOffersPrices implements client.PricesAPI.
+func (c *Client) DeleteAllOffersPrices(ctx context.Context, campaignID int64, opts ...client.CallOption) error {
+	c.record("DeleteAllOffersPrices", campaignID, opts)
+
+	if c.DeleteAllOffersPricesFunc == nil {
+		return nil
+	}
+
+	return c.DeleteAllOffersPricesFunc(ctx, campaignID, opts...)
+}
+
+// DeleteOfferPrices implements client.PricesAPI.
+func (c *Client) DeleteOfferPrices(
+	ctx context.Context,
+	campaignID int64,
+	offers []models.OfferRef,
+	opts ...client.BatchOption,
+) (client.BatchResult, error) {
+	c.record("DeleteOfferPrices", campaignID, offers, opts)
+
+	if c.DeleteOfferPricesFunc == nil {
+		return client.BatchResult{}, nil
+	}
+
+	return c.DeleteOfferPricesFunc(ctx, campaignID, offers, opts...)
+}
+
+// SetBusinessOfferPrices implements client.BusinessPricesAPI.
+func (c *Client) SetBusinessOfferPrices(
+	ctx context.Context,
+	businessID int64,
+	offers []models.BusinessOfferPrice,
+	opts ...client.CallOption,
+) error {
+	c.record("SetBusinessOfferPrices", businessID, offers, opts)
+
+	if c.SetBusinessOfferPricesFunc == nil {
+		return nil
+	}
+
+	return c.SetBusinessOfferPricesFunc(ctx, businessID, offers, opts...)
+}
+
+// GetBusinessOfferPrices implements client.BusinessPricesAPI.
+func (c *Client) GetBusinessOfferPrices(
+	ctx context.Context,
+	businessID int64,
+	opts ...models.GetBusinessPricesOption,
+) (models.BusinessPricesResult, error) {
+	c.record("GetBusinessOfferPrices", businessID, opts)
+
+	if c.GetBusinessOfferPricesFunc == nil {
+		return models.BusinessPricesResult{}, nil
+	}
+
+	return c.GetBusinessOfferPricesFunc(ctx, businessID, opts...)
+}
+
+// HideOffers implements client.HiddenOffersAPI.
+func (c *Client) HideOffers(
+	ctx context.Context,
+	campaignID int64,
+	offersToHide []models.HiddenOffer,
+	opts ...client.CallOption,
+) error {
+	c.record("HideOffers", campaignID, offersToHide, opts)
+
+	if c.HideOffersFunc == nil {
+		return nil
+	}
+
+	return c.HideOffersFunc(ctx, campaignID, offersToHide, opts...)
+}
+
+// GetHiddenOffers implements client.HiddenOffersAPI.
+func (c *Client) GetHiddenOffers(
+	ctx context.Context,
+	campaignID int64,
+	opts ...models.GetHiddenOffersOption,
+) (models.GetHiddenOfferResult, error) {
+	c.record("GetHiddenOffers", campaignID, opts)
+
+	if c.GetHiddenOffersFunc == nil {
+		return models.GetHiddenOfferResult{}, nil
+	}
+
+	return c.GetHiddenOffersFunc(ctx, campaignID, opts...)
+}
+
+// UnhideOffers implements client.HiddenOffersAPI.
+func (c *Client) UnhideOffers(
+	ctx context.Context,
+	campaignID int64,
+	offersToUnhide []models.OfferToUnhide,
+	opts ...client.CallOption,
+) error {
+	c.record("UnhideOffers", campaignID, offersToUnhide, opts)
+
+	if c.UnhideOffersFunc == nil {
+		return nil
+	}
+
+	return c.UnhideOffersFunc(ctx, campaignID, offersToUnhide, opts...)
+}
+
+// ExploreOffers implements client.OffersAPI.
+func (c *Client) ExploreOffers(
+	ctx context.Context,
+	campaignID int64,
+	opts ...models.ExploreOption,
+) (models.ExploreOffersResponse, error) {
+	c.record("ExploreOffers", campaignID, opts)
+
+	if c.ExploreOffersFunc == nil {
+		return models.ExploreOffersResponse{}, nil
+	}
+
+	return c.ExploreOffersFunc(ctx, campaignID, opts...)
+}
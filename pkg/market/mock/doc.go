@@ -0,0 +1,14 @@
+// Package mock contains client.MarketAPI implementation for unit tests.
+//
+// Client records every call and returns responses scripted with its function fields,
+// methods without scripted function return zero values:
+//
+//	m := &mock.Client{
+//		ListFeedsFunc: func(ctx context.Context, campaignID int64, opts ...client.CallOption) ([]models.Feed, error) {
+//			return []models.Feed{{ID: 1}}, nil
+//		},
+//	}
+//
+//	feeds, err := m.ListFeeds(ctx, 1)
+//	calls := m.CallsTo("ListFeeds")
+package mock
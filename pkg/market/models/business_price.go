@@ -0,0 +1,51 @@
+package models
+
+// SetBusinessPricesRequest is a body of business prices update request.
+type SetBusinessPricesRequest struct {
+	Offers []BusinessOfferPrice `json:"offers"`
+}
+
+// BusinessOfferPrice is a price of offer set for all campaigns of a business.
+// Offer is identified by its id only, without feed.
+type BusinessOfferPrice struct {
+	OfferID string        `json:"offerId"`
+	Price   BusinessPrice `json:"price"`
+	// CofinancePrice is a price seller agrees to sell offer at in promotions cofinanced by Yandex.Market.
+	CofinancePrice *CofinancePrice `json:"cofinancePrice,omitempty"`
+}
+
+// BusinessPrice describes business offer price.
+type BusinessPrice struct {
+	CurrencyID   Currency `json:"currencyId"`
+	Value        float64  `json:"value"`
+	DiscountBase float64  `json:"discountBase,omitempty"`
+	// MinimumForBestseller is a minimum price offer takes part in bestseller promotions with.
+	MinimumForBestseller float64 `json:"minimumForBestseller,omitempty"`
+	// UpdatedAt is returned by listing of prices only.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// CofinancePrice describes price for cofinanced promotions.
+type CofinancePrice struct {
+	CurrencyID Currency `json:"currencyId"`
+	Value      float64  `json:"value"`
+}
+
+// GetBusinessPricesRequest is a body of business prices listing request.
+type GetBusinessPricesRequest struct {
+	OfferIDs []string `json:"offerIds,omitempty"`
+	Archived bool     `json:"archived,omitempty"`
+}
+
+// GetBusinessPricesResponse is a business prices listing response.
+type GetBusinessPricesResponse struct {
+	Errors CommonErrors         `json:"errors"`
+	Result BusinessPricesResult `json:"result"`
+	Status Status               `json:"status"`
+}
+
+// BusinessPricesResult is a business prices listing result.
+type BusinessPricesResult struct {
+	Offers []BusinessOfferPrice `json:"offers"`
+	Paging Paging               `json:"paging"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// GetBusinessPricesOptions describes filter and pagination options for business prices listing.
+// Docs: https://yandex.ru/dev/market/partner-api/doc/ru/reference/business-assortment/getPricesByOfferIds .
+type GetBusinessPricesOptions struct {
+	PageToken string
+	Limit     int32
+
+	OfferIDs []string
+	Archived bool
+}
+
+// GetBusinessPricesOption modifies GetBusinessPricesOptions.
+type GetBusinessPricesOption func(*GetBusinessPricesOptions)
+
+// ToQueryArgs converts pagination options to query args according to documentation of yandex market API.
+func (o GetBusinessPricesOptions) ToQueryArgs() url.Values {
+	query := url.Values{}
+
+	if o.PageToken != "" {
+		query.Set("page_token", o.PageToken)
+	}
+
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(int(o.Limit)))
+	}
+
+	return query
+}
+
+// ToRequest converts filter options to request body.
+func (o GetBusinessPricesOptions) ToRequest() GetBusinessPricesRequest {
+	return GetBusinessPricesRequest{
+		OfferIDs: o.OfferIDs,
+		Archived: o.Archived,
+	}
+}
+
+// WithPageTokenBusinessPriceOption sets page token.
+func WithPageTokenBusinessPriceOption(token string) GetBusinessPricesOption {
+	return func(o *GetBusinessPricesOptions) {
+		o.PageToken = token
+	}
+}
+
+// WithLimitBusinessPriceOption sets number of offers on a page.
+func WithLimitBusinessPriceOption(limit int32) GetBusinessPricesOption {
+	return func(o *GetBusinessPricesOptions) {
+		o.Limit = limit
+	}
+}
+
+// WithOfferIDsBusinessPriceOption limits listing to offers with ids.
+func WithOfferIDsBusinessPriceOption(offerIDs ...string) GetBusinessPricesOption {
+	return func(o *GetBusinessPricesOptions) {
+		o.OfferIDs = append(o.OfferIDs, offerIDs...)
+	}
+}
+
+// WithArchivedBusinessPriceOption lists prices of archived offers.
+func WithArchivedBusinessPriceOption(archived bool) GetBusinessPricesOption {
+	return func(o *GetBusinessPricesOptions) {
+		o.Archived = archived
+	}
+}
@@ -7,8 +7,8 @@ import (
 
 // CommonResponse response structure common for most of responses.
 type CommonResponse struct {
-	Errors CommonErrors `json:"errors"`
-	Status Status       `json:"status"`
+	Errors CommonErrors `json:"errors" xml:"errors>error"`
+	Status Status       `json:"status" xml:"status"`
 }
 
 // CommonErrors list of CommonError.
@@ -25,8 +25,8 @@ func (e CommonErrors) Error() string {
 
 // CommonError error structure common for most of responses.
 type CommonError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string `json:"code" xml:"code,attr"`
+	Message string `json:"message" xml:"message,attr"`
 }
 
 // Error implement error interface.
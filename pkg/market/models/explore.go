@@ -2,33 +2,33 @@ package models
 
 // ExploreOffersResponse explore response structure.
 type ExploreOffersResponse struct {
-	Offers []OfferExploreModel `json:"offers"`
-	Pager  Pager               `json:"pager"`
+	Offers []OfferExploreModel `json:"offers" xml:"offers>offer"`
+	Pager  Pager               `json:"pager" xml:"pager"`
 }
 
 // OfferExploreModel explore response offer model.
 type OfferExploreModel struct {
-	Bid              float64 `json:"bid"`
-	Currency         string  `json:"currency"`
-	CutPrice         bool    `json:"cutPrice"`
-	Discount         int64   `json:"discount"`
-	FeedID           int64   `json:"feedId"`
-	ID               string  `json:"id"`
-	MarketCategoryID int64   `json:"marketCategoryId"`
-	ModelID          int64   `json:"modelId"`
-	PreDiscountPrice float64 `json:"preDiscountPrice"`
-	Price            float64 `json:"price"`
-	ShopCategoryID   string  `json:"shopCategoryId"`
-	Name             string  `json:"name"`
-	URL              string  `json:"url"`
+	Bid              float64 `json:"bid" xml:"bid,attr"`
+	Currency         string  `json:"currency" xml:"currency,attr"`
+	CutPrice         bool    `json:"cutPrice" xml:"cut-price,attr"`
+	Discount         int64   `json:"discount" xml:"discount,attr"`
+	FeedID           int64   `json:"feedId" xml:"feed-id,attr"`
+	ID               string  `json:"id" xml:"id,attr"`
+	MarketCategoryID int64   `json:"marketCategoryId" xml:"market-category-id,attr"`
+	ModelID          int64   `json:"modelId" xml:"model-id,attr"`
+	PreDiscountPrice float64 `json:"preDiscountPrice" xml:"pre-discount-price,attr"`
+	Price            float64 `json:"price" xml:"price,attr"`
+	ShopCategoryID   string  `json:"shopCategoryId" xml:"shop-category-id,attr"`
+	Name             string  `json:"name" xml:"name,attr"`
+	URL              string  `json:"url" xml:"url,attr"`
 }
 
 // Pager describes pagination status.
 type Pager struct {
-	CurrentPage int64 `json:"currentPage"`
-	From        int64 `json:"from"`
-	PagesCount  int64 `json:"pagesCount"`
-	PageSize    int64 `json:"pageSize"`
-	To          int64 `json:"to"`
-	Total       int64 `json:"total"`
+	CurrentPage int64 `json:"currentPage" xml:"current-page,attr"`
+	From        int64 `json:"from" xml:"from,attr"`
+	PagesCount  int64 `json:"pagesCount" xml:"pages-count,attr"`
+	PageSize    int64 `json:"pageSize" xml:"page-size,attr"`
+	To          int64 `json:"to" xml:"to,attr"`
+	Total       int64 `json:"total" xml:"total,attr"`
 }
@@ -2,42 +2,42 @@ package models
 
 // FeedResponse feeds response structure.
 type FeedResponse struct {
-	Feeds []Feed `json:"feeds"`
+	Feeds []Feed `json:"feeds" xml:"feeds>feed"`
 }
 
 // Feed feed structure.
 type Feed struct {
-	ID          int64       `json:"id"`
-	URL         string      `json:"url"`
-	Download    Download    `json:"download"`
-	Content     Content     `json:"content"`
-	Publication Publication `json:"publication"`
-	Placement   Download    `json:"placement"`
+	ID          int64       `json:"id" xml:"id,attr"`
+	URL         string      `json:"url" xml:"url,attr"`
+	Download    Download    `json:"download" xml:"download"`
+	Content     Content     `json:"content" xml:"content"`
+	Publication Publication `json:"publication" xml:"publication"`
+	Placement   Download    `json:"placement" xml:"placement"`
 }
 
 // Content describes feed offer status.
 type Content struct {
-	Status              Status `json:"status"`
-	TotalOffersCount    int64  `json:"totalOffersCount"`
-	RejectedOffersCount int64  `json:"rejectedOffersCount"`
+	Status              Status `json:"status" xml:"status,attr"`
+	TotalOffersCount    int64  `json:"totalOffersCount" xml:"total-offers-count,attr"`
+	RejectedOffersCount int64  `json:"rejectedOffersCount" xml:"rejected-offers-count,attr"`
 }
 
 // Download describes download status.
 type Download struct {
-	Status Status `json:"status"`
+	Status Status `json:"status" xml:"status,attr"`
 }
 
 // Publication describes publication status.
 type Publication struct {
-	Full                Time   `json:"full"`
-	PriceAndStockUpdate Time   `json:"priceAndStockUpdate"`
-	Status              Status `json:"status"`
+	Full                Time   `json:"full" xml:"full"`
+	PriceAndStockUpdate Time   `json:"priceAndStockUpdate" xml:"price-and-stock-update"`
+	Status              Status `json:"status" xml:"status,attr"`
 }
 
 // Time describes action time.
 type Time struct {
-	FileTime      string `json:"fileTime"`
-	PublishedTime string `json:"publishedTime"`
+	FileTime      string `json:"fileTime" xml:"file-time,attr"`
+	PublishedTime string `json:"publishedTime" xml:"published-time,attr"`
 }
 
 // Status is a status.
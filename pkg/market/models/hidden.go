@@ -2,44 +2,44 @@ package models
 
 // OfferHideRequest hide offers request body structure.
 type OfferHideRequest struct {
-	HiddenOffers []HiddenOffer `json:"hiddenOffers"`
+	HiddenOffers []HiddenOffer `json:"hiddenOffers" xml:"hidden-offers>hidden-offer"`
 }
 
 // HiddenOffer is a structure of offer to hide.
 type HiddenOffer struct {
-	FeedID     int64  `json:"feedId"`
-	OfferID    string `json:"offerId"`
-	Comment    string `json:"comment"`
-	TTLInHours int64  `json:"ttlInHours"`
+	FeedID     int64  `json:"feedId" xml:"feed-id,attr"`
+	OfferID    string `json:"offerId" xml:"offer-id,attr"`
+	Comment    string `json:"comment" xml:"comment,attr"`
+	TTLInHours int64  `json:"ttlInHours" xml:"ttl-in-hours,attr"`
 }
 
 // GetHiddenOfferResponse response structure.
 type GetHiddenOfferResponse struct {
-	Errors CommonErrors         `json:"errors"`
-	Result GetHiddenOfferResult `json:"result"`
-	Status Status               `json:"status"`
+	Errors CommonErrors         `json:"errors" xml:"errors>error"`
+	Result GetHiddenOfferResult `json:"result" xml:"result"`
+	Status Status               `json:"status" xml:"status"`
 }
 
 // GetHiddenOfferResult get hidden offers result structure.
 type GetHiddenOfferResult struct {
-	HiddenOffers []HiddenOffer `json:"hiddenOffers"`
-	Total        int64         `json:"total"`
-	Paging       Paging        `json:"paging"`
+	HiddenOffers []HiddenOffer `json:"hiddenOffers" xml:"hidden-offers>hidden-offer"`
+	Total        int64         `json:"total" xml:"total"`
+	Paging       Paging        `json:"paging" xml:"paging"`
 }
 
 // Paging contains page tokens to use in further requests.
 type Paging struct {
-	PrevPageToken string `json:"prevPageToken"`
-	NextPageToken string `json:"nextPageToken"`
+	PrevPageToken string `json:"prevPageToken" xml:"prev-page-token,attr"`
+	NextPageToken string `json:"nextPageToken" xml:"next-page-token,attr"`
 }
 
 // OfferUnhideRequest unhide request body.
 type OfferUnhideRequest struct {
-	HiddenOffers []OfferToUnhide `json:"hiddenOffers"`
+	HiddenOffers []OfferToUnhide `json:"hiddenOffers" xml:"hidden-offers>hidden-offer"`
 }
 
 // OfferToUnhide describes offer to unhide.
 type OfferToUnhide struct {
-	FeedID  int64  `json:"feedId"`
-	OfferID string `json:"offerId"`
+	FeedID  int64  `json:"feedId" xml:"feed-id,attr"`
+	OfferID string `json:"offerId" xml:"offer-id,attr"`
 }
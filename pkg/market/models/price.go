@@ -2,51 +2,64 @@ package models
 
 // SetPriceRequest is set price body.
 type SetPriceRequest struct {
-	Offers []Offer `json:"offers"`
+	Offers []Offer `json:"offers" xml:"offers>offer"`
 }
 
 // Offer describes offer structure.
 type Offer struct {
-	Feed   FeedObj `json:"feed"`
-	ID     string  `json:"id"`
-	Delete bool    `json:"delete"`
-	Price  Price   `json:"price"`
+	Feed   FeedObj `json:"feed" xml:"feed"`
+	ID     string  `json:"id" xml:"id,attr"`
+	Delete bool    `json:"delete" xml:"delete,attr"`
+	Price  Price   `json:"price" xml:"price"`
 }
 
 // FeedObj describes feed.
 type FeedObj struct {
-	ID int64 `json:"id"`
+	ID int64 `json:"id" xml:"id,attr"`
 }
 
 // Price describes offer price.
 type Price struct {
-	CurrencyID   Currency `json:"currencyId"`
-	Value        float64  `json:"value"`
-	DiscountBase float64  `json:"discountBase,omitempty"`
+	CurrencyID   Currency `json:"currencyId" xml:"currency-id,attr"`
+	Value        float64  `json:"value" xml:"value,attr"`
+	DiscountBase float64  `json:"discountBase,omitempty" xml:"discount-base,attr,omitempty"`
 }
 
 // SetPriceResponse set price response structure.
 type SetPriceResponse struct {
-	Status Status `json:"status"`
+	Status Status `json:"status" xml:"status"`
 }
 
 // GetPricesResponse get price response structure.
 type GetPricesResponse struct {
-	Errors CommonErrors `json:"errors"`
-	Result Result       `json:"result"`
-	Status Status       `json:"status"`
+	Errors CommonErrors `json:"errors" xml:"errors>error"`
+	Result Result       `json:"result" xml:"result"`
+	Status Status       `json:"status" xml:"status"`
 }
 
 // Result is a get prices response result.
 type Result struct {
-	Offers []GetPriceOfferModel `json:"offers"`
-	Total  int64                `json:"total"`
+	Offers []GetPriceOfferModel `json:"offers" xml:"offers>offer"`
+	Total  int64                `json:"total" xml:"total"`
 }
 
 // GetPriceOfferModel offer model for get price response.
 type GetPriceOfferModel struct {
-	Feed      Feed   `json:"feed"`
-	ID        string `json:"id"`
-	Price     Price  `json:"price"`
-	UpdatedAt string `json:"updatedAt"`
+	Feed      Feed   `json:"feed" xml:"feed"`
+	ID        string `json:"id" xml:"id,attr"`
+	Price     Price  `json:"price" xml:"price"`
+	UpdatedAt string `json:"updatedAt" xml:"updated-at,attr"`
+}
+
+// RemovePricesRequest is a body of request deleting prices set with API.
+// Either RemoveAll is set or Offers are listed.
+type RemovePricesRequest struct {
+	RemoveAll bool       `json:"removeAll,omitempty" xml:"remove-all,omitempty"`
+	Offers    []OfferRef `json:"offers,omitempty" xml:"offers>offer,omitempty"`
+}
+
+// OfferRef identifies offer of the feed.
+type OfferRef struct {
+	Feed FeedObj `json:"feed" xml:"feed"`
+	ID   string  `json:"id" xml:"id,attr"`
 }
@@ -0,0 +1,2 @@
+// Package tracing contains opentelemetry adapter for client.Tracer.
+package tracing
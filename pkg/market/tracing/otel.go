@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+// InstrumentationName is a name of tracer used to create spans.
+const InstrumentationName = "github.com/KazanExpress/yandex-market"
+
+// Span attributes set on API call spans.
+const (
+	AttributeCampaignID = attribute.Key("yandex_market.campaign_id")
+	AttributeBusinessID = attribute.Key("yandex_market.business_id")
+	AttributeGroup      = attribute.Key("yandex_market.group")
+	AttributeItems      = attribute.Key("yandex_market.offers_count")
+	AttributeAPIStatus  = attribute.Key("yandex_market.api_status")
+	AttributeErrorCodes = attribute.Key("yandex_market.error_codes")
+	AttributeRetries    = attribute.Key("yandex_market.retries")
+	AttributeCacheHit   = attribute.Key("yandex_market.cache_hit")
+	AttributeHTTPMethod = attribute.Key("http.method")
+	AttributeHTTPPath   = attribute.Key("http.target")
+	AttributeHTTPStatus = attribute.Key("http.status_code")
+)
+
+// OTelTracer starts opentelemetry span for every API call.
+// Spans are named after client methods and are children of span from context passed to the method.
+type OTelTracer struct {
+	tracer trace.Tracer
+}
+
+var _ client.Tracer = (*OTelTracer)(nil)
+
+// NewOTelTracer is OTelTracer constructor, nil provider means global tracer provider.
+func NewOTelTracer(provider trace.TracerProvider) *OTelTracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return &OTelTracer{
+		tracer: provider.Tracer(InstrumentationName),
+	}
+}
+
+// StartCall implements client.Tracer.
+// Spans of business calls have AttributeBusinessID instead of AttributeCampaignID.
+func (t *OTelTracer) StartCall(ctx context.Context, info client.CallInfo) (context.Context, func(client.CallEvent)) {
+	scope := AttributeCampaignID.Int64(info.CampaignID)
+	if info.BusinessID != 0 {
+		scope = AttributeBusinessID.Int64(info.BusinessID)
+	}
+
+	ctx, span := t.tracer.Start(ctx, info.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			scope,
+			AttributeGroup.String(info.Group),
+			AttributeItems.Int(info.Items),
+			AttributeHTTPMethod.String(info.Method),
+			AttributeHTTPPath.String(info.Path),
+		),
+	)
+
+	return ctx, func(event client.CallEvent) {
+		defer span.End()
+
+		span.SetAttributes(AttributeRetries.Int(event.Retries), AttributeCacheHit.Bool(event.CacheHit))
+
+		if event.HTTPStatus != 0 {
+			span.SetAttributes(AttributeHTTPStatus.Int(event.HTTPStatus))
+		}
+
+		if event.APIStatus != "" {
+			span.SetAttributes(AttributeAPIStatus.String(string(event.APIStatus)))
+		}
+
+		if len(event.APIErrors) > 0 {
+			errorCodes := make([]string, 0, len(event.APIErrors))
+			for _, apiErr := range event.APIErrors {
+				errorCodes = append(errorCodes, apiErr.Code)
+			}
+
+			span.SetAttributes(AttributeErrorCodes.StringSlice(errorCodes))
+		}
+
+		switch {
+		case event.Err != nil:
+			span.RecordError(event.Err)
+			span.SetStatus(codes.Error, event.Err.Error())
+		case event.APIStatus.IsError():
+			span.SetStatus(codes.Error, event.APIErrors.Error())
+		}
+	}
+}
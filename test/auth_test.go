@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+func TestYandexMarketClient_Authenticators(t *testing.T) {
+	tests := []struct {
+		name       string
+		option     client.Option
+		wantHeader string
+		wantValue  string
+	}{
+		{
+			name:       "legacy oauth",
+			option:     client.WithOAuth("token", "client-id"),
+			wantHeader: "Authorization",
+			wantValue:  "OAuth oauth_token=token, oauth_client_id=client-id",
+		},
+		{
+			name:       "bearer oauth",
+			option:     client.WithBearerToken("token"),
+			wantHeader: "Authorization",
+			wantValue:  "Bearer token",
+		},
+		{
+			name:       "api key",
+			option:     client.WithAPIKey("key"),
+			wantHeader: "Api-Key",
+			wantValue:  "key",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				_, _ = w.Write([]byte(`{"feeds":[]}`))
+			}))
+			defer server.Close()
+
+			c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), tt.option)
+
+			_, err := c.ListFeeds(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValue, header.Get(tt.wantHeader))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func makeOffers(n int) []models.Offer {
+	offers := make([]models.Offer, n)
+	for i := range offers {
+		offers[i] = models.Offer{
+			Feed:  models.FeedObj{ID: 1},
+			ID:    fmt.Sprintf("offer-%d", i),
+			Price: models.Price{CurrencyID: models.CurrencyRUR, Value: float64(i + 1)},
+		}
+	}
+
+	return offers
+}
+
+var fastChunkRetries = client.WithChunkRetryPolicy(client.RetryPolicy{
+	MaxAttempts:        3,
+	BaseDelay:          time.Millisecond,
+	RetryNonIdempotent: true,
+})
+
+func TestSetOfferPricesBatched(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.InjectFault(fake.Fault{Method: http.MethodPost, Status: http.StatusServiceUnavailable, Count: 1})
+
+	c := newFakeClient(server)
+
+	result, err := c.SetOfferPricesBatched(context.Background(), 1, makeOffers(4500),
+		client.WithBatchWorkers(3), fastChunkRetries)
+	require.NoError(t, err)
+
+	require.Len(t, result.Chunks, 3)
+	assert.Len(t, result.Chunks[0].OfferIDs, client.MaxOfferPricesPerCall)
+	assert.Len(t, result.Chunks[2].OfferIDs, 500)
+	assert.Equal(t, "offer-4000", result.Chunks[2].OfferIDs[0])
+
+	attempts := 0
+	for _, chunk := range result.Chunks {
+		assert.Equal(t, models.StatusOk, chunk.Status)
+
+		attempts += chunk.Attempts
+	}
+
+	assert.Equal(t, 4, attempts, "chunk failed with 503 should be resubmitted")
+	assert.Len(t, server.Prices(1), 4500)
+	assert.Empty(t, result.ChunkErrorsByOffer())
+}
+
+func TestSetOfferPricesBatched_errors(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.InjectFault(fake.Fault{
+		Method:  http.MethodPost,
+		Status:  http.StatusBadRequest,
+		Code:    "BAD_PRICE",
+		Message: "price is too low",
+		Count:   1,
+	})
+
+	c := newFakeClient(server)
+
+	result, err := c.Campaign(1).Prices().SetBatched(context.Background(), makeOffers(25),
+		client.WithChunkSize(10), fastChunkRetries)
+	require.True(t, errors.Is(err, client.ErrBadRequest))
+
+	require.Len(t, result.Chunks, 3)
+
+	failed := result.FailedChunks()
+	require.Len(t, failed, 1)
+	assert.Equal(t, 0, failed[0].Index)
+	assert.Equal(t, 1, failed[0].Attempts, "rejected chunk should not be resubmitted")
+	assert.Equal(t, models.StatusError, failed[0].Status)
+
+	offerErrors := result.ChunkErrorsByOffer()
+	require.Len(t, offerErrors, 10)
+	assert.Equal(t, "BAD_PRICE", offerErrors["offer-0"][0].Code)
+	assert.Len(t, server.Prices(1), 15)
+}
+
+func TestSetOfferPricesBatched_cancel(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := c.SetOfferPricesBatched(ctx, 1, makeOffers(30), client.WithChunkSize(10))
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Len(t, result.Chunks, 3)
+	assert.Len(t, result.ChunkErrorsByOffer(), 30)
+	assert.Empty(t, server.Requests())
+}
+
+func TestDeleteOfferPrices(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	offers := makeOffers(2500)
+	_, err := c.SetOfferPricesBatched(ctx, 1, offers)
+	require.NoError(t, err)
+
+	refs := make([]models.OfferRef, 0, 2100)
+	for _, offer := range offers[:2100] {
+		refs = append(refs, models.OfferRef{Feed: offer.Feed, ID: offer.ID})
+	}
+
+	result, err := c.DeleteOfferPrices(ctx, 1, refs)
+	require.NoError(t, err)
+	require.Len(t, result.Chunks, 2)
+	assert.Len(t, result.Chunks[1].OfferIDs, 100)
+
+	prices := server.Prices(1)
+	require.Len(t, prices, 400, "only listed offers should be deleted")
+	assert.Equal(t, "offer-2100", prices[0].ID)
+}
+
+func TestSetOfferPricesBatched_clientRetries(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.InjectFault(fake.Fault{Method: http.MethodPost, Status: http.StatusServiceUnavailable})
+
+	c := newFakeClient(server, client.WithRetryPolicy(client.RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          time.Millisecond,
+		RetryNonIdempotent: true,
+	}))
+
+	result, err := c.SetOfferPricesBatched(context.Background(), 1, makeOffers(10),
+		client.WithBatchWorkers(0), fastChunkRetries)
+	require.True(t, errors.Is(err, client.ErrServerError))
+
+	require.Len(t, result.Chunks, 1)
+	assert.Equal(t, 3, result.Chunks[0].Attempts)
+	assert.Len(t, server.Requests(), 3, "client retries should not multiply chunk resubmits")
+}
+
+func TestSetOfferPricesBatched_retryNonIdempotent(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.InjectFault(fake.Fault{Method: http.MethodPost, Status: http.StatusServiceUnavailable, Count: 1})
+
+	c := newFakeClient(server)
+
+	result, err := c.SetOfferPricesBatched(context.Background(), 1, makeOffers(10),
+		client.WithChunkRetryPolicy(client.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	require.True(t, errors.Is(err, client.ErrServerError))
+	assert.Equal(t, 1, result.Chunks[0].Attempts, "chunks are resubmitted only with RetryNonIdempotent")
+
+	assert.True(t, client.DefaultChunkRetryPolicy.RetryNonIdempotent)
+}
+
+func TestSetOfferPricesBatched_callRetryPolicy(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+
+	_, err := c.SetOfferPricesBatched(context.Background(), 1, makeOffers(10),
+		client.WithBatchCallOptions(client.WithCallRetryPolicy(client.NoRetries)))
+	require.True(t, errors.Is(err, client.ErrBatchCallRetryPolicy))
+	assert.Empty(t, server.Requests())
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/metrics"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	core, logs := observer.New(zap.WarnLevel)
+	prom := metrics.NewPrometheusRecorder("")
+
+	c := newFakeClient(server,
+		client.WithLogger(zap.New(core)),
+		client.WithMetrics(prom),
+		client.WithCircuitBreaker(client.CircuitBreaker{
+			PerGroup:     true,
+			FailureRatio: 0.5,
+			MinRequests:  2,
+			Window:       time.Minute,
+			OpenTimeout:  50 * time.Millisecond,
+		}),
+	)
+	ctx := context.Background()
+
+	server.InjectFault(fake.Fault{PathContains: "feeds", Status: http.StatusBadGateway, Count: 2})
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ListFeeds(ctx, 1)
+		require.True(t, errors.Is(err, client.ErrServerError))
+	}
+
+	_, err := c.ListFeeds(ctx, 1)
+	require.True(t, errors.Is(err, client.ErrCircuitOpen))
+
+	var openErr *client.CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, client.RateGroupFeeds, openErr.Group)
+	assert.Positive(t, int64(openErr.RetryAfter))
+	assert.Len(t, server.Requests(), 2, "open circuit should fail fast")
+
+	_, err = c.GetOfferPrices(ctx, 1)
+	assert.NoError(t, err, "circuits of other groups should stay closed")
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = c.ListFeeds(ctx, 1)
+	require.NoError(t, err, "probe should be let through after open timeout")
+
+	_, err = c.ListFeeds(ctx, 1)
+	require.NoError(t, err, "successful probe should close circuit")
+
+	changes := logs.FilterMessage("yandex market circuit breaker state changed").All()
+	require.Len(t, changes, 3)
+	assert.Equal(t, "open", changes[0].ContextMap()["to"])
+	assert.Equal(t, "half-open", changes[1].ContextMap()["to"])
+	assert.Equal(t, "closed", changes[2].ContextMap()["to"])
+
+	expected := `
+# HELP yandex_market_circuit_breaker_state State of circuit breaker: 0 is closed, 1 is open, 2 is half-open.
+# TYPE yandex_market_circuit_breaker_state gauge
+yandex_market_circuit_breaker_state{group="feeds"} 0
+`
+	assert.NoError(t, testutil.CollectAndCompare(prom, strings.NewReader(expected), "yandex_market_circuit_breaker_state"))
+}
+
+func TestCircuitBreaker_failedProbe(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server, client.WithCircuitBreaker(client.CircuitBreaker{
+		FailureRatio: 1,
+		MinRequests:  1,
+		Window:       time.Minute,
+		OpenTimeout:  20 * time.Millisecond,
+	}))
+	ctx := context.Background()
+
+	server.InjectFault(fake.Fault{Status: http.StatusServiceUnavailable, Count: 2})
+
+	_, err := c.ListFeeds(ctx, 1)
+	require.True(t, errors.Is(err, client.ErrServerError))
+
+	_, err = c.GetOfferPrices(ctx, 1)
+	require.True(t, errors.Is(err, client.ErrCircuitOpen), "global circuit should reject all groups")
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err = c.GetOfferPrices(ctx, 1)
+	require.True(t, errors.Is(err, client.ErrServerError))
+
+	_, err = c.GetOfferPrices(ctx, 1)
+	require.True(t, errors.Is(err, client.ErrCircuitOpen), "failed probe should open circuit again")
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestBulkExecutor(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	for campaignID := int64(1); campaignID <= 3; campaignID++ {
+		server.AddFeeds(campaignID, models.Feed{ID: campaignID * 10})
+	}
+
+	server.InjectFault(fake.Fault{PathContains: "campaigns/2/offer-prices", Status: http.StatusBadGateway})
+
+	c := newFakeClient(server)
+	offers := []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a"}}
+
+	var operations []client.BulkOperation
+	for campaignID := int64(1); campaignID <= 3; campaignID++ {
+		operations = append(operations,
+			client.BulkListFeeds(campaignID),
+			client.BulkSetOfferPrices(campaignID, offers),
+			client.BulkHideOffers(campaignID, []models.HiddenOffer{{FeedID: 1, OfferID: "a"}}),
+		)
+	}
+
+	results := client.NewBulkExecutor(c, 2).Run(context.Background(), operations)
+	require.Len(t, results, len(operations))
+
+	feeds, ok := results[3].Value.([]models.Feed)
+	require.True(t, ok)
+	require.Len(t, feeds, 1)
+	assert.Equal(t, int64(20), feeds[0].ID)
+
+	failed := results.Failed()
+	require.Len(t, failed, 1, "failed operation should not stop the rest")
+	assert.Equal(t, "SetOfferPrices", failed[0].Operation.Name)
+	assert.Equal(t, int64(2), failed[0].Operation.CampaignID)
+	assert.True(t, errors.Is(results.Err(), client.ErrServerError))
+
+	assert.Len(t, server.Prices(3), 1)
+	assert.Len(t, server.HiddenOffers(2), 1)
+}
+
+func TestBulkExecutor_concurrencyAndCancel(t *testing.T) {
+	c := client.NewYandexMarketClient()
+
+	var running, maxRunning int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operations := make([]client.BulkOperation, 10)
+	for i := range operations {
+		i := i
+		operations[i] = client.BulkOperation{
+			CampaignID: int64(i),
+			Run: func(ctx context.Context, campaign *client.CampaignClient) (interface{}, error) {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+
+				for {
+					prev := atomic.LoadInt32(&maxRunning)
+					if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+
+				if i == 3 {
+					cancel()
+				}
+
+				return campaign.ID(), nil
+			},
+		}
+	}
+
+	results := client.NewBulkExecutor(c, 3).Run(ctx, operations)
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+	assert.Equal(t, int64(0), results[0].Value)
+
+	canceled := 0
+
+	for _, result := range results {
+		if errors.Is(result.Err, context.Canceled) {
+			canceled++
+		}
+	}
+
+	assert.Positive(t, canceled, "operations should not be started after cancel")
+}
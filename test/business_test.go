@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func businessPrice(id string, value float64) models.BusinessOfferPrice {
+	return models.BusinessOfferPrice{
+		OfferID: id,
+		Price:   models.BusinessPrice{CurrencyID: models.CurrencyRUR, Value: value},
+	}
+}
+
+func TestBusinessPrices(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	offer := businessPrice("a", 100)
+	offer.Price.DiscountBase = 120
+	offer.Price.MinimumForBestseller = 90
+	offer.CofinancePrice = &models.CofinancePrice{CurrencyID: models.CurrencyRUR, Value: 95}
+
+	require.NoError(t, c.SetBusinessOfferPrices(ctx, 3, []models.BusinessOfferPrice{
+		offer, businessPrice("c", 300), businessPrice("b", 200),
+	}))
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/businesses/3/offer-prices/updates", requests[0].URL.Path)
+
+	stored := server.BusinessPrices(3)
+	require.Len(t, stored, 3)
+	assert.Equal(t, "a", stored[0].OfferID)
+	assert.Empty(t, server.Prices(3), "business prices are not campaign prices")
+
+	result, err := c.Business(3).Prices().List(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Offers, 3)
+	assert.Empty(t, result.Paging.NextPageToken)
+
+	got := result.Offers[0]
+	assert.NotEmpty(t, got.Price.UpdatedAt)
+	got.Price.UpdatedAt = ""
+	assert.Equal(t, offer, got)
+	assert.Nil(t, result.Offers[1].CofinancePrice)
+
+	result, err = c.GetBusinessOfferPrices(ctx, 3, models.WithOfferIDsBusinessPriceOption("c", "missing"))
+	require.NoError(t, err)
+	require.Len(t, result.Offers, 1)
+	assert.Equal(t, "c", result.Offers[0].OfferID)
+
+	var ids []string
+
+	var opts []models.GetBusinessPricesOption
+
+	for {
+		result, err = c.GetBusinessOfferPrices(ctx, 3, append(opts, models.WithLimitBusinessPriceOption(2))...)
+		require.NoError(t, err)
+
+		for _, price := range result.Offers {
+			ids = append(ids, price.OfferID)
+		}
+
+		if result.Paging.NextPageToken == "" {
+			break
+		}
+
+		opts = []models.GetBusinessPricesOption{models.WithPageTokenBusinessPriceOption(result.Paging.NextPageToken)}
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
+
+func TestBusinessPrices_limit(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+
+	err := c.SetBusinessOfferPrices(context.Background(), 3,
+		make([]models.BusinessOfferPrice, client.MaxBusinessOfferPricesPerCall+1))
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Empty(t, server.BusinessPrices(3))
+}
+
+func TestBusinessPrices_dryRun(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	var skipped []client.DryRunRequest
+
+	c := newFakeClient(server, client.WithDryRun(func(req client.DryRunRequest) {
+		skipped = append(skipped, req)
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, c.SetBusinessOfferPrices(ctx, 3, []models.BusinessOfferPrice{businessPrice("a", 100)}))
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "SetBusinessOfferPrices", skipped[0].Operation)
+	assert.Empty(t, server.Requests())
+
+	_, err := c.GetBusinessOfferPrices(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, server.Requests(), 1, "listing is sent despite POST method")
+
+	err = c.SetBusinessOfferPrices(ctx, 3, make([]models.BusinessOfferPrice, client.MaxBusinessOfferPricesPerCall+1))
+	assert.True(t, errors.Is(err, client.ErrTooManyItems))
+}
+
+func TestBusinessPrices_xmlClient(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	events := &eventsRecorder{}
+	c := newFakeClient(server, client.WithFormat(client.FormatXML), client.WithMetrics(events))
+	ctx := context.Background()
+
+	require.NoError(t, c.SetBusinessOfferPrices(ctx, 3, []models.BusinessOfferPrice{businessPrice("a", 100)}))
+
+	result, err := c.GetBusinessOfferPrices(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, result.Offers, 1)
+
+	requests := server.Requests()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/businesses/3/offer-prices/updates", requests[0].URL.Path)
+	assert.Equal(t, "/businesses/3/offer-prices", requests[1].URL.Path)
+	assert.Contains(t, requests[0].Header.Get("Content-Type"), "json", "business calls are sent in json")
+
+	require.Len(t, events.events, 2)
+	assert.Equal(t, client.RateGroupBusinessOfferPriceUpdates, events.events[0].Group)
+	assert.Equal(t, client.RateGroupBusinessOfferPrices, events.events[1].Group)
+	assert.Equal(t, int64(3), events.events[0].BusinessID)
+	assert.Zero(t, events.events[0].CampaignID)
+}
+
+func TestBusinessPrices_retries(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	ctx := context.Background()
+
+	server.InjectFault(fake.Fault{PathContains: "offer-prices", Status: http.StatusServiceUnavailable, Count: 1})
+
+	_, err := c.GetBusinessOfferPrices(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, server.Requests(), 2, "listing should be retried despite POST method")
+
+	server.InjectFault(fake.Fault{PathContains: "offer-prices", Status: http.StatusServiceUnavailable, Count: 1})
+
+	err = c.SetBusinessOfferPrices(ctx, 3, []models.BusinessOfferPrice{businessPrice("a", 100)})
+	require.True(t, errors.Is(err, client.ErrServerError))
+	assert.Len(t, server.Requests(), 3, "update should not be retried")
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/tracing"
+)
+
+func TestYandexMarketClient_ResponseCache(t *testing.T) {
+	var (
+		listCalls    int32
+		notModified  int32
+		refreshCalls int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			atomic.AddInt32(&refreshCalls, 1)
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+
+			return
+		}
+
+		atomic.AddInt32(&listCalls, 1)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"feeds":[{"id":1},{"id":2}]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithResponseCache(nil, map[string]time.Duration{"ListFeeds": 50 * time.Millisecond}),
+	)
+
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		feeds, err := c.ListFeeds(ctx, 1)
+		require.NoError(t, err)
+		assert.Len(t, feeds, 2)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&listCalls), "fresh response should be served from cache")
+
+	_, err := c.ListFeeds(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&listCalls), "campaigns should be cached separately")
+
+	time.Sleep(60 * time.Millisecond)
+
+	feeds, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, feeds, 2)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&listCalls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified), "expired response should be revalidated with etag")
+
+	require.NoError(t, c.RefreshFeed(ctx, 1, 1))
+
+	_, err = c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&listCalls), "refresh should invalidate cached feeds")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+}
+
+func TestYandexMarketClient_ResponseCacheDefaultTTLs(t *testing.T) {
+	var listCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&listCalls, 1)
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"feeds":[{"id":1}]}`))
+	}))
+	defer server.Close()
+
+	events := &eventsRecorder{}
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithResponseCache(nil, nil),
+		client.WithMetrics(events),
+		client.WithTracer(tracing.NewOTelTracer(provider)),
+	)
+
+	ctx := context.Background()
+
+	_, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+
+	var raw *http.Response
+
+	feeds, err := c.ListFeeds(ctx, 1, client.WithRawResponse(&raw))
+	require.NoError(t, err)
+	assert.Len(t, feeds, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&listCalls), "ListFeeds should be cached with DefaultCacheTTLs")
+
+	require.NotNil(t, raw, "raw response should be filled on cache hit")
+	assert.Equal(t, http.StatusOK, raw.StatusCode)
+	assert.Equal(t, "req-1", raw.Header.Get("X-Request-Id"))
+
+	require.Len(t, events.events, 2)
+	assert.False(t, events.events[0].CacheHit)
+	assert.True(t, events.events[1].CacheHit)
+	assert.Equal(t, "ListFeeds", events.events[1].Operation)
+	assert.Equal(t, 0, events.events[1].Retries)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	attrs := attribute.NewSet(spans[1].Attributes...)
+
+	cacheHit, ok := attrs.Value(tracing.AttributeCacheHit)
+	require.True(t, ok)
+	assert.True(t, cacheHit.AsBool())
+}
+
+func TestYandexMarketClient_ResponseCacheSharedOption(t *testing.T) {
+	var listCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&listCalls, 1)
+		_, _ = w.Write([]byte(`{"feeds":[{"id":1}]}`))
+	}))
+	defer server.Close()
+
+	cache := client.WithResponseCache(nil, nil)
+	ctx := context.Background()
+
+	for _, token := range []string{"token-1", "token-2"} {
+		c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), client.WithOAuth(token, "client"), cache)
+
+		_, err := c.ListFeeds(ctx, 1)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&listCalls), "clients should not share default cache")
+}
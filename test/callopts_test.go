@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestCallOptions_headersAndRawResponse(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+
+	var raw *http.Response
+
+	_, err := c.ListFeeds(context.Background(), 1,
+		client.WithHeader("X-Debug", "1"),
+		client.WithIdempotencyKey("key-1"),
+		client.WithRawResponse(&raw),
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, raw)
+	assert.Equal(t, http.StatusOK, raw.StatusCode)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "1", requests[0].Header.Get("X-Debug"))
+	assert.Equal(t, "key-1", requests[0].Header.Get(client.IdempotencyKeyHeader))
+}
+
+func TestCallOptions_timeout(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.SetLatency(50 * time.Millisecond)
+
+	c := newFakeClient(server)
+
+	ctx := client.WithCallOptions(context.Background(), client.WithCallTimeout(10*time.Millisecond))
+
+	_, err := c.ExploreOffers(ctx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = c.Campaign(1).Prices().List(ctx, models.WithLimitAndOffsetPriceOption(10, 0))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = c.GetHiddenOffers(ctx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = c.Business(3).Prices().List(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = c.GetOfferPrices(context.Background(), 1)
+	assert.NoError(t, err, "timeout should apply only to calls with options")
+}
+
+func TestCallOptions_retryPolicy(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	fault := fake.Fault{Method: http.MethodPost, Status: http.StatusServiceUnavailable, Count: 1}
+	policy := client.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	offers := []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a"}}
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	server.InjectFault(fault)
+	err := c.SetOfferPrices(ctx, 1, offers, client.WithCallRetryPolicy(policy))
+	assert.True(t, errors.Is(err, client.ErrServerError), "POST without idempotency key should not be retried")
+
+	server.InjectFault(fault)
+	err = c.SetOfferPrices(ctx, 1, offers, client.WithCallRetryPolicy(policy), client.WithIdempotencyKey("prices-1"))
+	require.NoError(t, err)
+	assert.Len(t, server.Prices(1), 1)
+}
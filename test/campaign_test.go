@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestCampaignClient(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(7, models.Feed{ID: 1})
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	campaign := c.Campaign(7)
+	assert.Equal(t, int64(7), campaign.ID())
+	assert.Equal(t, int64(3), c.Business(3).ID())
+
+	feeds, err := campaign.Feeds().List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, feeds, 1)
+	require.NoError(t, campaign.Feeds().Refresh(ctx, 1))
+
+	require.NoError(t, campaign.Prices().Set(ctx, []models.Offer{
+		{Feed: models.FeedObj{ID: 1}, ID: "a", Price: models.Price{CurrencyID: models.CurrencyRUR, Value: 10}},
+	}))
+
+	// flat methods and scoped services share the same campaign state.
+	prices, err := c.GetOfferPrices(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, "a", prices[0].ID)
+
+	require.NoError(t, campaign.Prices().DeleteAll(ctx))
+	prices, err = campaign.Prices().List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+
+	hidden := campaign.HiddenOffers()
+	require.NoError(t, hidden.Hide(ctx, []models.HiddenOffer{{FeedID: 1, OfferID: "a", TTLInHours: 1}}))
+
+	result, err := hidden.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, result.HiddenOffers, 1)
+
+	require.NoError(t, hidden.Unhide(ctx, []models.OfferToUnhide{{FeedID: 1, OfferID: "a"}}))
+	assert.Empty(t, server.HiddenOffers(7))
+
+	explored, err := campaign.Offers().Explore(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, explored.Offers)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/cassette"
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"feeds":[{"id":7}]}`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := cassette.New(path, cassette.ModeRecord)
+	require.NoError(t, err)
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithOAuth("secret-token", "secret-client"),
+		client.WithHTTPClient(recorder.Client()),
+	)
+
+	feeds, err := c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	require.NoError(t, recorder.Save())
+
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), "secret-client")
+
+	player, err := cassette.New(path, cassette.ModeReplay)
+	require.NoError(t, err)
+
+	c = client.NewYandexMarketClient(
+		client.WithAPIEndpoint("http://offline.invalid/"),
+		client.WithHTTPClient(player.Client()),
+		client.WithRetryPolicy(client.NoRetries),
+	)
+
+	feeds, err = c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, feeds, 1)
+	assert.Equal(t, int64(7), feeds[0].ID)
+
+	_, err = c.ListFeeds(context.Background(), 1)
+	assert.ErrorIs(t, err, cassette.ErrNoInteraction, "each interaction should be replayed once")
+}
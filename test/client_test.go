@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/KazanExpress/yandex-market/pkg/market/cassette"
 	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
 	"github.com/KazanExpress/yandex-market/pkg/market/models"
 )
 
@@ -20,17 +24,83 @@ func TestMain(t *testing.M) {
 	os.Exit(t.Run())
 }
 
-func getClient() *client.YandexMarketClient {
+// IDs the in-memory fake server is seeded with, they are used unless tests run against live API.
+const (
+	fakeCampaignID = 1
+	fakeOfferID    = "offer-1"
+	fakeFeedID     = 1
+)
+
+// recordsLiveAPI reports whether tests send requests to live API.
+func recordsLiveAPI() bool {
+	return os.Getenv("RECORD_CASSETTES") != ""
+}
+
+// getClient returns client for API tests.
+// By default it talks to in-memory fake server seeded by newSeededFakeServer, so tests check the client
+// against fake server only. With RECORD_CASSETTES env set requests are sent to live API
+// and interactions are recorded to test cassette with secrets scrubbed.
+func getClient(t *testing.T) *client.YandexMarketClient {
+	t.Helper()
+
+	if !recordsLiveAPI() {
+		server := newSeededFakeServer()
+		t.Cleanup(server.Close)
+
+		return client.NewYandexMarketClient(
+			client.WithUserAgent("Chromium"),
+			client.WithAPIEndpoint(server.URL()),
+		)
+	}
+
+	name := strings.ReplaceAll(t.Name(), "/", "_") + ".json"
+
+	recorder, err := cassette.New(filepath.Join("testdata", "cassettes", name), cassette.ModeRecord)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, recorder.Save())
+	})
+
 	return client.NewYandexMarketClient(
 		client.WithUserAgent("Chromium"),
 		client.WithOAuth(os.Getenv("OAUTH_TOKEN"), os.Getenv("OAUTH_CLIENT_ID")),
 		client.WithHTTPClient(&http.Client{
-			Timeout: time.Second * 10,
+			Timeout:   time.Second * 10,
+			Transport: recorder,
 		}),
 	)
 }
 
+// newSeededFakeServer returns fake server with campaign API tests run against by default.
+func newSeededFakeServer() *fake.Server {
+	server := fake.NewServer()
+
+	for id := int64(1); id <= 5; id++ {
+		server.AddFeeds(fakeCampaignID, models.Feed{
+			ID:  id,
+			URL: fmt.Sprintf("https://example.com/feeds/%d.xml", id),
+		})
+	}
+
+	for i := 1; i <= 25; i++ {
+		server.AddOffers(fakeCampaignID, models.OfferExploreModel{
+			ID:       fmt.Sprintf("offer-%d", i),
+			FeedID:   fakeFeedID,
+			Name:     fmt.Sprintf("Offer %d", i),
+			Price:    float64(250 + i),
+			Currency: string(models.CurrencyRUR),
+		})
+	}
+
+	return server
+}
+
 func getCampaign() int64 {
+	if !recordsLiveAPI() {
+		return fakeCampaignID
+	}
+
 	camp := os.Getenv("CAMPAIGN")
 	if camp == "" {
 		return 1
@@ -44,7 +114,20 @@ func getCampaign() int64 {
 	return res
 }
 
+func getOfferID() string {
+	offerID := os.Getenv("OFFER_ID")
+	if !recordsLiveAPI() || offerID == "" {
+		return fakeOfferID
+	}
+
+	return offerID
+}
+
 func getFeedID() int64 {
+	if !recordsLiveAPI() {
+		return fakeFeedID
+	}
+
 	feedID := os.Getenv("FEED_ID")
 	if feedID == "" {
 		return 1
@@ -79,7 +162,7 @@ func TestYandexMarketClient_ListFeeds(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := getClient()
+			c := getClient(t)
 			got, err := c.ListFeeds(context.Background(), tt.args.campaignID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("YandexMarketClient.ListFeeds() error = %v, wantErr %v", err, tt.wantErr)
@@ -105,7 +188,7 @@ func TestYandexMarketClient_RefreshFeed(t *testing.T) {
 	}
 
 	tests := []test{}
-	c := getClient()
+	c := getClient(t)
 	campaignID := getCampaign()
 	feeds, err := c.ListFeeds(context.Background(), campaignID)
 	if err != nil {
@@ -130,9 +213,9 @@ func TestYandexMarketClient_RefreshFeed(t *testing.T) {
 }
 
 func TestYandexMarketClient_Prices(t *testing.T) {
-	c := getClient()
+	c := getClient(t)
 	campaignID := getCampaign()
-	offerID := os.Getenv("OFFER_ID")
+	offerID := getOfferID()
 	feedID := getFeedID()
 	discountBase := 300.0
 	price := 250.0
@@ -165,9 +248,9 @@ func TestYandexMarketClient_Prices(t *testing.T) {
 }
 
 func TestYandexMarketClient_Hidden(t *testing.T) {
-	c := getClient()
+	c := getClient(t)
 	campaignID := getCampaign()
-	offerID := os.Getenv("OFFER_ID")
+	offerID := getOfferID()
 	feedID := getFeedID()
 	comment := "Временно закончился на складе"
 
@@ -208,7 +291,7 @@ func TestYandexMarketClient_Hidden(t *testing.T) {
 }
 
 func TestYandexMarketClient_Explore(t *testing.T) {
-	c := getClient()
+	c := getClient(t)
 	campaignID := getCampaign()
 
 	result, err := c.ExploreOffers(context.Background(), campaignID, models.WithPaginationExploreOption(1, 10))
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestYandexMarketClient_Do(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(1, models.Feed{ID: 5})
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	feeds := models.FeedResponse{}
+	require.NoError(t, c.Do(ctx, http.MethodGet, "v2/campaigns/1/feeds.json", nil, nil, &feeds))
+	require.Len(t, feeds.Feeds, 1)
+	assert.Equal(t, int64(5), feeds.Feeds[0].ID)
+
+	request := models.SetPriceRequest{Offers: []models.Offer{
+		{Feed: models.FeedObj{ID: 5}, ID: "a", Price: models.Price{CurrencyID: models.CurrencyRUR, Value: 1}},
+	}}
+	require.NoError(t, c.Do(ctx, http.MethodPost, "/v2/campaigns/1/offer-prices/updates", nil, request, nil))
+	assert.Len(t, server.Prices(1), 1)
+
+	prices := models.GetPricesResponse{}
+	query := url.Values{"limit": []string{"1"}}
+	require.NoError(t, c.Do(ctx, http.MethodGet, "/v2/campaigns/1/offer-prices", query, nil, &prices))
+	assert.Len(t, prices.Result.Offers, 1)
+
+	err := c.Do(ctx, http.MethodPost, "/v2/campaigns/1/feeds/6/refresh", nil, []byte(`{}`), nil)
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+}
+
+func TestYandexMarketClient_Do_errorStatus(t *testing.T) {
+	var gotHeader http.Header
+
+	var gotBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header
+		gotBody, _ = ioutil.ReadAll(r.Body)
+
+		_, _ = w.Write([]byte(`{"status":"ERROR","errors":[{"code":"BAD_REQUEST","message":"bad offer"}]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithAPIKey("key"),
+		client.WithUserAgent("test-agent"),
+	)
+
+	err := c.Do(context.Background(), http.MethodPut, "/v2/some-endpoint", nil, []byte(`{"a":1}`), nil)
+	require.Error(t, err)
+
+	var errs models.CommonErrors
+	require.True(t, errors.As(err, &errs))
+	assert.Equal(t, "BAD_REQUEST", errs[0].Code)
+
+	assert.Equal(t, "key", gotHeader.Get("Api-Key"))
+	assert.Equal(t, "test-agent", gotHeader.Get("User-Agent"))
+	assert.Equal(t, `{"a":1}`, string(gotBody))
+}
+
+func TestYandexMarketClient_Do_paths(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(1, models.Feed{ID: 5})
+
+	ctx := context.Background()
+
+	feeds := models.FeedResponse{}
+	require.NoError(t, newFakeClient(server).Do(ctx, http.MethodGet, "/v2/campaigns/1/feeds.xml", nil, nil, &feeds))
+	require.Len(t, feeds.Feeds, 1)
+	assert.Equal(t, "/v2/campaigns/1/feeds.json", server.Requests()[0].URL.Path, "suffix of other format is replaced")
+
+	c := newFakeClient(server, client.WithFormat(client.FormatXML))
+	request := models.SetBusinessPricesRequest{Offers: []models.BusinessOfferPrice{
+		{OfferID: "a", Price: models.BusinessPrice{CurrencyID: models.CurrencyRUR, Value: 1}},
+	}}
+
+	require.NoError(t, c.Do(ctx, http.MethodPost, "/businesses/3/offer-prices/updates", nil, request, nil,
+		client.WithCallJSONOnly()))
+	assert.Equal(t, "/businesses/3/offer-prices/updates", server.Requests()[1].URL.Path)
+	assert.Len(t, server.BusinessPrices(3), 1)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestDryRun(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(1, models.Feed{ID: 1})
+
+	core, logs := observer.New(zap.InfoLevel)
+
+	var skipped []client.DryRunRequest
+
+	c := newFakeClient(server,
+		client.WithLogger(zap.New(core)),
+		client.WithDryRun(func(req client.DryRunRequest) {
+			skipped = append(skipped, req)
+		}),
+	)
+	ctx := context.Background()
+
+	offers := []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a", Price: models.Price{Value: 10}}}
+
+	require.NoError(t, c.SetOfferPrices(ctx, 1, offers))
+	require.NoError(t, c.DeleteAllOffersPrices(ctx, 1))
+	require.NoError(t, c.HideOffers(ctx, 1, []models.HiddenOffer{{FeedID: 1, OfferID: "a"}}))
+	require.NoError(t, c.UnhideOffers(ctx, 1, []models.OfferToUnhide{{FeedID: 1, OfferID: "a"}}))
+	require.NoError(t, c.RefreshFeed(ctx, 1, 1))
+
+	assert.Empty(t, server.Requests(), "mutating calls should not be sent")
+	require.Len(t, skipped, 5)
+	assert.Equal(t, "SetOfferPrices", skipped[0].Operation)
+	assert.Equal(t, int64(1), skipped[0].CampaignID)
+	assert.Contains(t, string(skipped[0].Body), `"id":"a"`)
+	assert.Equal(t, 5, logs.FilterMessage("yandex market dry run").Len())
+
+	_, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, server.Requests(), 1, "GET calls should be sent")
+
+	err = c.SetOfferPrices(ctx, 1, make([]models.Offer, client.MaxOfferPricesPerCall+1))
+	assert.True(t, errors.Is(err, client.ErrTooManyItems))
+
+	err = c.HideOffers(ctx, 1, make([]models.HiddenOffer, client.MaxHiddenOffersPerCall+1))
+	assert.True(t, errors.Is(err, client.ErrTooManyItems))
+
+	require.NoError(t, c.SetOfferPrices(ctx, 1, offers, client.WithCallDryRun(false)))
+	assert.Len(t, server.Prices(1), 1, "call option should disable dry-run")
+}
+
+func TestDryRun_callOption(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+
+	require.NoError(t, c.DeleteAllOffersPrices(context.Background(), 1, client.WithCallDryRun(true)))
+	assert.Empty(t, server.Requests())
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestYandexMarketClient_APIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		sentinel   error
+		wantErrors models.CommonErrors
+	}{
+		{
+			name:     "unauthorized with json body",
+			status:   http.StatusUnauthorized,
+			body:     `{"status":"ERROR","errors":[{"code":"UNAUTHORIZED","message":"bad token"}]}`,
+			sentinel: client.ErrUnauthorized,
+			wantErrors: models.CommonErrors{
+				{Code: "UNAUTHORIZED", Message: "bad token"},
+			},
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			body:     `{"status":"ERROR","errors":[{"code":"NOT_FOUND","message":"campaign not found"}]}`,
+			sentinel: client.ErrNotFound,
+			wantErrors: models.CommonErrors{
+				{Code: "NOT_FOUND", Message: "campaign not found"},
+			},
+		},
+		{
+			name:     "enhance your calm",
+			status:   client.StatusEnhanceYourCalm,
+			body:     `{"status":"ERROR","errors":[{"code":"LIMIT_EXCEEDED","message":"too many calls"}]}`,
+			sentinel: client.ErrRateLimited,
+			wantErrors: models.CommonErrors{
+				{Code: "LIMIT_EXCEEDED", Message: "too many calls"},
+			},
+		},
+		{
+			name:     "server error with html body",
+			status:   http.StatusBadGateway,
+			body:     `<html>bad gateway</html>`,
+			sentinel: client.ErrServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-1")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			c := client.NewYandexMarketClient(
+				client.WithAPIEndpoint(server.URL),
+				client.WithRetryPolicy(client.NoRetries),
+			)
+
+			_, err := c.ListFeeds(context.Background(), 1)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.sentinel), "error %v should match sentinel %v", err, tt.sentinel)
+
+			var apiErr *client.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			assert.Equal(t, http.MethodGet, apiErr.Method)
+			assert.Equal(t, "/v2/campaigns/1/feeds.json", apiErr.Path)
+			assert.Equal(t, "req-1", apiErr.RequestID)
+			assert.Equal(t, tt.body, apiErr.Body)
+			assert.Equal(t, tt.wantErrors, apiErr.Errors)
+		})
+	}
+}
+
+func TestYandexMarketClient_APIErrorTruncatedBody(t *testing.T) {
+	// cyrillic message starts one byte before the limit, so its first rune straddles the cut.
+	body := strings.Repeat("x", 1023) + "ошибка сервера"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRetryPolicy(client.NoRetries),
+	)
+
+	_, err := c.ListFeeds(context.Background(), 1)
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.True(t, utf8.ValidString(apiErr.Body), "body should not end with a split rune")
+	assert.Equal(t, body[:1023], apiErr.Body)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func newFakeClient(server *fake.Server, opts ...client.Option) *client.YandexMarketClient {
+	return client.NewYandexMarketClient(append([]client.Option{
+		client.WithAPIEndpoint(server.URL()),
+		client.WithRetryPolicy(client.NoRetries),
+	}, opts...)...)
+}
+
+func TestFakeServer_Prices(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	err := c.SetOfferPrices(ctx, 1, []models.Offer{
+		{Feed: models.FeedObj{ID: 1}, ID: "b", Price: models.Price{CurrencyID: models.CurrencyRUR, Value: 200}},
+		{Feed: models.FeedObj{ID: 1}, ID: "a", Price: models.Price{CurrencyID: models.CurrencyRUR, Value: 100}},
+	})
+	require.NoError(t, err)
+
+	prices, err := c.GetOfferPrices(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, "a", prices[0].ID)
+	assert.Equal(t, 100.0, prices[0].Price.Value)
+
+	prices, err = c.GetOfferPrices(ctx, 1, models.WithLimitAndOffsetPriceOption(1, 1))
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, "b", prices[0].ID)
+
+	prices, err = c.GetOfferPrices(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, prices, "campaigns should not share state")
+
+	err = c.SetOfferPrices(ctx, 1, make([]models.Offer, fake.MaxOfferPricesPerCall+1))
+	assert.True(t, errors.Is(err, client.ErrBadRequest))
+
+	require.NoError(t, c.DeleteAllOffersPrices(ctx, 1))
+	assert.Empty(t, server.Prices(1))
+}
+
+func TestFakeServer_Hidden(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	require.NoError(t, c.HideOffers(ctx, 1, []models.HiddenOffer{
+		{FeedID: 1, OfferID: "a", TTLInHours: 1},
+		{FeedID: 1, OfferID: "b", TTLInHours: 1},
+	}))
+
+	result, err := c.GetHiddenOffers(ctx, 1, models.WithOfferID("b"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+
+	require.NoError(t, c.UnhideOffers(ctx, 1, []models.OfferToUnhide{{FeedID: 1, OfferID: "a"}}))
+
+	result, err = c.GetHiddenOffers(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, result.HiddenOffers, 1)
+	assert.Equal(t, "b", result.HiddenOffers[0].OfferID)
+
+	err = c.HideOffers(ctx, 1, make([]models.HiddenOffer, fake.MaxHiddenOffersPerCall+1))
+	assert.True(t, errors.Is(err, client.ErrBadRequest))
+}
+
+func TestFakeServer_FeedsAndOffers(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(1, models.Feed{ID: 10}, models.Feed{ID: 11})
+	server.AddOffers(1,
+		models.OfferExploreModel{ID: "a", FeedID: 10, Name: "Red phone"},
+		models.OfferExploreModel{ID: "b", FeedID: 10, Name: "Blue phone", ModelID: 5},
+		models.OfferExploreModel{ID: "c", FeedID: 11, Name: "Red case"},
+	)
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	feeds, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, feeds, 2)
+
+	require.NoError(t, c.RefreshFeed(ctx, 1, 10))
+	assert.True(t, errors.Is(c.RefreshFeed(ctx, 1, 99), client.ErrNotFound))
+
+	result, err := c.ExploreOffers(ctx, 1, models.WithQueryExploreOption("red"), models.WithPaginationExploreOption(1, 1))
+	require.NoError(t, err)
+	require.Len(t, result.Offers, 1)
+	assert.Equal(t, "a", result.Offers[0].ID)
+	assert.Equal(t, int64(2), result.Pager.Total)
+	assert.Equal(t, int64(2), result.Pager.PagesCount)
+
+	result, err = c.ExploreOffers(ctx, 1, models.WithMatchedExploreOption(true))
+	require.NoError(t, err)
+	require.Len(t, result.Offers, 1)
+	assert.Equal(t, "b", result.Offers[0].ID)
+}
+
+func TestFakeServer_Faults(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.InjectFault(fake.Fault{
+		Method:       http.MethodGet,
+		PathContains: "feeds",
+		Status:       http.StatusServiceUnavailable,
+		Count:        1,
+	})
+
+	c := newFakeClient(server)
+	ctx := context.Background()
+
+	_, err := c.ListFeeds(ctx, 1)
+	assert.True(t, errors.Is(err, client.ErrServerError))
+
+	_, err = c.ListFeeds(ctx, 1)
+	assert.NoError(t, err, "fault should be injected only once")
+
+	server.SetLatency(50 * time.Millisecond)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.ListFeeds(timeoutCtx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Len(t, server.Requests(), 3)
+}
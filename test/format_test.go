@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestModels_roundTrip(t *testing.T) {
+	price := models.Price{CurrencyID: models.CurrencyRUR, Value: 100.5, DiscountBase: 120}
+	errs := models.CommonErrors{{Code: "BAD_REQUEST", Message: "bad offer"}}
+	hidden := models.HiddenOffer{FeedID: 1, OfferID: "a", Comment: "out of stock", TTLInHours: 12}
+	businessPrice := models.BusinessOfferPrice{
+		OfferID:        "a",
+		Price:          models.BusinessPrice{CurrencyID: models.CurrencyRUR, Value: 100, MinimumForBestseller: 90},
+		CofinancePrice: &models.CofinancePrice{CurrencyID: models.CurrencyRUR, Value: 95},
+	}
+
+	values := []interface{}{
+		models.CommonResponse{Status: models.StatusError, Errors: errs},
+		models.SetPriceRequest{Offers: []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a", Price: price}}},
+		models.RemovePricesRequest{RemoveAll: true},
+		models.RemovePricesRequest{Offers: []models.OfferRef{{Feed: models.FeedObj{ID: 1}, ID: "a"}}},
+		models.GetPricesResponse{
+			Status: models.StatusOk,
+			Errors: errs,
+			Result: models.Result{
+				Offers: []models.GetPriceOfferModel{{Feed: models.Feed{ID: 1}, ID: "a", Price: price, UpdatedAt: "2021-01-01"}},
+				Total:  1,
+			},
+		},
+		models.FeedResponse{Feeds: []models.Feed{{
+			ID:          1,
+			URL:         "https://example.com/feed.xml",
+			Download:    models.Download{Status: models.StatusOk},
+			Content:     models.Content{Status: models.StatusOk, TotalOffersCount: 10, RejectedOffersCount: 1},
+			Publication: models.Publication{Full: models.Time{FileTime: "t1", PublishedTime: "t2"}, Status: models.StatusOk},
+			Placement:   models.Download{Status: models.StatusNa},
+		}}},
+		models.OfferHideRequest{HiddenOffers: []models.HiddenOffer{hidden}},
+		models.OfferUnhideRequest{HiddenOffers: []models.OfferToUnhide{{FeedID: 1, OfferID: "a"}}},
+		models.GetHiddenOfferResponse{
+			Status: models.StatusOk,
+			Result: models.GetHiddenOfferResult{
+				HiddenOffers: []models.HiddenOffer{hidden},
+				Total:        1,
+				Paging:       models.Paging{NextPageToken: "next"},
+			},
+		},
+		models.ExploreOffersResponse{
+			Offers: []models.OfferExploreModel{{ID: "a", FeedID: 1, Name: "phone", Price: 10, CutPrice: true}},
+			Pager:  models.Pager{CurrentPage: 1, PagesCount: 1, PageSize: 10, To: 1, Total: 1},
+		},
+	}
+
+	// business endpoints accept only json.
+	jsonOnlyValues := []interface{}{
+		models.SetBusinessPricesRequest{Offers: []models.BusinessOfferPrice{businessPrice}},
+		models.GetBusinessPricesRequest{OfferIDs: []string{"a", "b"}},
+		models.GetBusinessPricesResponse{
+			Status: models.StatusOk,
+			Result: models.BusinessPricesResult{
+				Offers: []models.BusinessOfferPrice{businessPrice},
+				Paging: models.Paging{NextPageToken: "next"},
+			},
+		},
+	}
+
+	formats := map[string]struct {
+		marshal   func(interface{}) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}{
+		"json": {json.Marshal, json.Unmarshal},
+		"xml":  {xml.Marshal, xml.Unmarshal},
+	}
+
+	for name, format := range formats {
+		cases := values
+		if name == "json" {
+			cases = append(append([]interface{}{}, values...), jsonOnlyValues...)
+		}
+
+		for _, value := range cases {
+			t.Run(fmt.Sprintf("%s %T", name, value), func(t *testing.T) {
+				data, err := format.marshal(value)
+				require.NoError(t, err)
+
+				decoded := reflect.New(reflect.TypeOf(value))
+				require.NoError(t, format.unmarshal(data, decoded.Interface()))
+				assert.Equal(t, value, decoded.Elem().Interface())
+			})
+		}
+	}
+}
+
+func TestYandexMarketClient_formats(t *testing.T) {
+	for _, format := range []client.Format{client.FormatJSON, client.FormatXML} {
+		format := format
+
+		t.Run(string(format), func(t *testing.T) {
+			server := fake.NewServer()
+			defer server.Close()
+
+			server.AddFeeds(1, models.Feed{ID: 1, URL: "https://example.com/feed"})
+			server.AddOffers(1, models.OfferExploreModel{ID: "a", FeedID: 1, Name: "phone"})
+
+			events := &eventsRecorder{}
+			c := newFakeClient(server, client.WithFormat(format), client.WithMetrics(events))
+			ctx := context.Background()
+
+			feeds, err := c.ListFeeds(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, feeds, 1)
+			assert.Equal(t, "https://example.com/feed", feeds[0].URL)
+			assert.Equal(t, client.RateGroupFeeds, events.events[0].Group)
+
+			price := models.Price{CurrencyID: models.CurrencyRUR, Value: 99.9}
+			require.NoError(t, c.SetOfferPrices(ctx, 1, []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a", Price: price}}))
+
+			prices, err := c.GetOfferPrices(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, prices, 1)
+			assert.Equal(t, price, prices[0].Price)
+
+			require.NoError(t, c.DeleteAllOffersPrices(ctx, 1))
+			assert.Empty(t, server.Prices(1))
+
+			require.NoError(t, c.HideOffers(ctx, 1, []models.HiddenOffer{{FeedID: 1, OfferID: "a", TTLInHours: 1}}))
+
+			hidden, err := c.GetHiddenOffers(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, hidden.HiddenOffers, 1)
+			assert.Equal(t, "a", hidden.HiddenOffers[0].OfferID)
+
+			require.NoError(t, c.UnhideOffers(ctx, 1, []models.OfferToUnhide{{FeedID: 1, OfferID: "a"}}))
+
+			explored, err := c.ExploreOffers(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, explored.Offers, 1)
+			assert.Equal(t, "phone", explored.Offers[0].Name)
+
+			requests := server.Requests()
+			assert.Contains(t, requests[0].URL.Path, "."+string(format))
+
+			err = c.RefreshFeed(ctx, 1, 2)
+
+			var apiErr *client.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+			require.Len(t, apiErr.Errors, 1)
+			assert.Equal(t, "NOT_FOUND", apiErr.Errors[0].Code)
+
+			require.NoError(t, c.RefreshFeed(ctx, 1, 1, client.WithCallDryRun(true)))
+		})
+	}
+}
+
+// TestModels_xmlPayloads decodes XML payloads laid out as in API documentation:
+// scalar fields are hyphenated attributes, nested objects are hyphenated elements.
+func TestModels_xmlPayloads(t *testing.T) {
+	var (
+		fixture     string
+		requestBody []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ = ioutil.ReadAll(r.Body)
+
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "xml", fixture))
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/xml;charset=utf-8")
+
+		if fixture == "error.xml" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithFormat(client.FormatXML),
+		client.WithRetryPolicy(client.NoRetries),
+	)
+	ctx := context.Background()
+
+	fixture = "feeds.xml"
+	feeds, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+	assert.Equal(t, models.Feed{
+		ID:       12345,
+		URL:      "https://example.com/feed.xml",
+		Download: models.Download{Status: models.StatusOk},
+		Content:  models.Content{Status: models.StatusOk, TotalOffersCount: 3452, RejectedOffersCount: 15},
+		Publication: models.Publication{
+			Full: models.Time{FileTime: "2021-04-17T15:15:49+03:00", PublishedTime: "2021-04-17T15:20:01+03:00"},
+			PriceAndStockUpdate: models.Time{
+				FileTime:      "2021-04-17T16:00:00+03:00",
+				PublishedTime: "2021-04-17T16:05:00+03:00",
+			},
+			Status: models.StatusOk,
+		},
+		Placement: models.Download{Status: models.StatusOk},
+	}, feeds[0])
+	assert.Equal(t, models.StatusError, feeds[1].Download.Status)
+
+	fixture = "offer-prices.xml"
+	prices, err := c.GetOfferPrices(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, "offer-1", prices[0].ID)
+	assert.Equal(t, int64(12345), prices[0].Feed.ID)
+	assert.Equal(t, "2021-11-21T00:42:42+03:00", prices[0].UpdatedAt)
+	assert.Equal(t, models.Price{CurrencyID: models.CurrencyRUR, Value: 1000, DiscountBase: 1200}, prices[0].Price)
+	assert.Equal(t, 250.5, prices[1].Price.Value)
+
+	fixture = "hidden-offers.xml"
+	hidden, err := c.GetHiddenOffers(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.GetHiddenOfferResult{
+		HiddenOffers: []models.HiddenOffer{{FeedID: 12345, OfferID: "offer-1", Comment: "Out of stock", TTLInHours: 12}},
+		Total:        1,
+		Paging:       models.Paging{NextPageToken: "c2tpcD0x"},
+	}, hidden)
+
+	fixture = "offers.xml"
+	explored, err := c.ExploreOffers(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, models.ExploreOffersResponse{
+		Offers: []models.OfferExploreModel{{
+			Currency:         "RUR",
+			Discount:         9,
+			FeedID:           12345,
+			ID:               "offer-1",
+			MarketCategoryID: 91491,
+			ModelID:          1732210983,
+			PreDiscountPrice: 21990,
+			Price:            19990,
+			ShopCategoryID:   "10",
+			Name:             "Smartphone",
+			URL:              "https://example.com/offers/1",
+		}},
+		Pager: models.Pager{CurrentPage: 1, From: 1, PagesCount: 1, PageSize: 10, To: 1, Total: 1},
+	}, explored)
+
+	fixture = "error.xml"
+	err = c.SetOfferPrices(ctx, 1, []models.Offer{{
+		Feed:  models.FeedObj{ID: 12345},
+		ID:    "offer-1",
+		Price: models.Price{CurrencyID: models.CurrencyRUR, Value: 1000},
+	}})
+	assert.Contains(t, string(requestBody),
+		`<offers><offer id="offer-1" delete="false"><feed id="12345"></feed><price currency-id="RUR" value="1000"></price>`)
+
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, models.CommonErrors{{Code: "BAD_REQUEST", Message: "Offer price is invalid"}}, apiErr.Errors)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestYandexMarketClient_RequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		level      client.RequestLogLevel
+		wantLevel  zapcore.Level
+		wantFields []string
+		noFields   []string
+	}{
+		{
+			name:       "info",
+			level:      client.RequestLogInfo,
+			wantLevel:  zapcore.InfoLevel,
+			wantFields: []string{"method", "path", "status", "api_status", "latency"},
+			noFields:   []string{"request_headers", "request_body"},
+		},
+		{
+			name:       "debug",
+			level:      client.RequestLogDebug,
+			wantLevel:  zapcore.DebugLevel,
+			wantFields: []string{"query", "request_headers", "request_size", "response_size"},
+			noFields:   []string{"request_body", "response_body"},
+		},
+		{
+			name:       "bodies",
+			level:      client.RequestLogBodies,
+			wantLevel:  zapcore.DebugLevel,
+			wantFields: []string{"request_body", "response_body"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			c := client.NewYandexMarketClient(
+				client.WithAPIEndpoint(server.URL),
+				client.WithOAuth("secret-token", "client-id"),
+				client.WithLogger(zap.New(core)),
+				client.WithRequestLogging(client.RequestLogging{Level: tt.level}),
+			)
+
+			require.NoError(t, c.SetOfferPrices(context.Background(), 1, []models.Offer{{ID: "1"}}))
+
+			entries := logs.FilterMessage("yandex market request").All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.wantLevel, entries[0].Level)
+
+			fields := entries[0].ContextMap()
+			for _, name := range tt.wantFields {
+				assert.Contains(t, fields, name)
+			}
+
+			for _, name := range tt.noFields {
+				assert.NotContains(t, fields, name)
+			}
+
+			if headers, ok := fields["request_headers"]; ok {
+				assert.Equal(t, "[REDACTED]", headers.(map[string]string)["Authorization"])
+			}
+
+			assert.NotContains(t, fmt.Sprint(fields), "secret-token")
+		})
+	}
+}
+
+func TestYandexMarketClient_RequestLoggingRedactsBeforeTruncation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK","access_token":"secret-token-that-straddles-the-limit"}`))
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithLogger(zap.New(core)),
+		client.WithRequestLogging(client.RequestLogging{Level: client.RequestLogBodies, MaxBodySize: 50}),
+	)
+
+	require.NoError(t, c.SetOfferPrices(context.Background(), 1, []models.Offer{{ID: "1"}}))
+
+	entries := logs.FilterMessage("yandex market request").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, `{"status":"OK","access_token":"[REDACTED]"}`, entries[0].ContextMap()["response_body"])
+}
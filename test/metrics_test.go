@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/metrics"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+type eventsRecorder struct {
+	events []client.CallEvent
+}
+
+func (r *eventsRecorder) RecordCall(event client.CallEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestYandexMarketClient_Metrics(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"status":"ERROR","errors":[{"code":"BAD_PRICE","message":"bad price"}]}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"OK","result":{"offers":[]}}`))
+	}))
+	defer server.Close()
+
+	events := &eventsRecorder{}
+	prom := metrics.NewPrometheusRecorder("")
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		client.WithMetrics(events),
+	)
+	promClient := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		client.WithMetrics(prom),
+	)
+
+	_, err := c.GetOfferPrices(context.Background(), 10)
+	require.NoError(t, err)
+
+	err = c.SetOfferPrices(context.Background(), 10, []models.Offer{{ID: "1"}})
+	require.Error(t, err)
+
+	require.Len(t, events.events, 2)
+
+	get := events.events[0]
+	assert.Equal(t, "GetOfferPrices", get.Operation)
+	assert.Equal(t, int64(10), get.CampaignID)
+	assert.Equal(t, client.RateGroupOfferPrices, get.Group)
+	assert.Equal(t, http.StatusOK, get.HTTPStatus)
+	assert.Equal(t, models.StatusOk, get.APIStatus)
+	assert.Equal(t, 1, get.Retries)
+	assert.NotZero(t, get.ResponseBytes)
+
+	set := events.events[1]
+	assert.Equal(t, "SetOfferPrices", set.Operation)
+	assert.Equal(t, models.StatusError, set.APIStatus)
+	assert.NotZero(t, set.RequestBytes)
+
+	require.Error(t, promClient.SetOfferPrices(context.Background(), 10, nil))
+	require.Error(t, promClient.SetBusinessOfferPrices(context.Background(), 3, nil))
+
+	expected := `
+# HELP yandex_market_requests_total Number of API calls.
+# TYPE yandex_market_requests_total counter
+yandex_market_requests_total{api_status="ERROR",business_id="",campaign_id="10",http_status="200",operation="SetOfferPrices"} 1
+yandex_market_requests_total{api_status="ERROR",business_id="3",campaign_id="",http_status="200",operation="SetBusinessOfferPrices"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(prom, strings.NewReader(expected), "yandex_market_requests_total"))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestYandexMarketClient_Middleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get("X-Audit"))
+		_, _ = w.Write([]byte(`{"status":"ERROR","errors":[{"code":"BAD_PRICE","message":"price is too low"}]}`))
+	}))
+	defer server.Close()
+
+	var (
+		order    []string
+		statuses []models.Status
+		errs     []models.CommonErrors
+		failures = 1
+	)
+
+	named := func(name string) client.Middleware {
+		return func(next client.Doer) client.Doer {
+			return client.DoerFunc(func(req *http.Request) (*client.Response, error) {
+				order = append(order, name)
+
+				return next.Do(req)
+			})
+		}
+	}
+
+	headers := func(next client.Doer) client.Doer {
+		return client.DoerFunc(func(req *http.Request) (*client.Response, error) {
+			req.Header.Set("X-Audit", "audit")
+
+			resp, err := next.Do(req)
+			if err == nil {
+				statuses = append(statuses, resp.Status)
+				errs = append(errs, resp.Errors)
+			}
+
+			return resp, err
+		})
+	}
+
+	faults := func(next client.Doer) client.Doer {
+		return client.DoerFunc(func(req *http.Request) (*client.Response, error) {
+			if failures > 0 {
+				failures--
+
+				return nil, errors.New("injected fault")
+			}
+
+			return next.Do(req)
+		})
+	}
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, RetryNonIdempotent: true, BaseDelay: time.Millisecond}),
+		client.WithMiddleware(named("outer"), named("inner")),
+		client.WithMiddleware(headers, faults),
+	)
+
+	err := c.SetOfferPrices(context.Background(), 1, []models.Offer{{ID: "1"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "price is too low")
+
+	assert.Equal(t, []string{"outer", "inner", "outer", "inner"}, order, "middleware should wrap every attempt")
+	assert.Equal(t, []models.Status{models.StatusError}, statuses)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "BAD_PRICE", errs[0][0].Code)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/mock"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// disablePrices is an example of consumer code depending on a small interface.
+func disablePrices(ctx context.Context, api client.PricesAPI, campaignID int64) error {
+	prices, err := api.GetOfferPrices(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	return api.DeleteAllOffersPrices(ctx, campaignID)
+}
+
+func TestMockClient(t *testing.T) {
+	m := &mock.Client{
+		GetOfferPricesFunc: func(
+			ctx context.Context,
+			campaignID int64,
+			opts ...models.GetOfferPricesOption,
+		) ([]models.GetPriceOfferModel, error) {
+			return []models.GetPriceOfferModel{{ID: "offer-1"}}, nil
+		},
+		DeleteAllOffersPricesFunc: func(ctx context.Context, campaignID int64, opts ...client.CallOption) error {
+			return client.ErrRateLimited
+		},
+	}
+
+	err := disablePrices(context.Background(), m, 42)
+	assert.True(t, errors.Is(err, client.ErrRateLimited))
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "GetOfferPrices", calls[0].Method)
+	assert.Equal(t, "DeleteAllOffersPrices", calls[1].Method)
+	assert.Equal(t, int64(42), calls[1].CampaignID)
+
+	require.NoError(t, m.HideOffers(context.Background(), 42, []models.HiddenOffer{{OfferID: "offer-1"}}))
+
+	hideCalls := m.CallsTo("HideOffers")
+	require.Len(t, hideCalls, 1)
+	assert.Equal(t, []models.HiddenOffer{{OfferID: "offer-1"}}, hideCalls[0].Args[0])
+
+	m.Reset()
+	assert.Empty(t, m.Calls())
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+func TestYandexMarketClient_Quota(t *testing.T) {
+	var remaining int64 = 100
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		left := atomic.AddInt64(&remaining, -45)
+
+		w.Header().Set(client.HeaderQuotaLimit, "100")
+		w.Header().Set(client.HeaderQuotaRemaining, "resource=offer-prices, "+strconv.FormatInt(left, 10))
+		w.Header().Set(client.HeaderQuotaUntil, "2026-10-17T12:00:00+03:00")
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	var lowCalls []client.Quota
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithLowQuotaCallback(0.2, func(campaignID int64, group string, quota client.Quota) {
+			assert.Equal(t, int64(7), campaignID)
+			assert.Equal(t, client.RateGroupOfferPrices, group)
+			lowCalls = append(lowCalls, quota)
+		}),
+	)
+
+	_, ok := c.Quota(7, client.RateGroupOfferPrices)
+	assert.False(t, ok)
+
+	require.NoError(t, c.SetOfferPrices(context.Background(), 7, []models.Offer{{ID: "1"}}))
+
+	quota, ok := c.Quota(7, client.RateGroupOfferPrices)
+	require.True(t, ok)
+	assert.Equal(t, int64(100), quota.Limit)
+	assert.Equal(t, int64(55), quota.Remaining)
+	assert.False(t, quota.Until.IsZero())
+	assert.Empty(t, lowCalls)
+
+	require.NoError(t, c.SetOfferPrices(context.Background(), 7, []models.Offer{{ID: "1"}}))
+
+	quota, _ = c.Quota(7, client.RateGroupOfferPrices)
+	assert.Equal(t, int64(10), quota.Remaining)
+	require.Len(t, lowCalls, 1)
+	assert.Equal(t, quota, lowCalls[0])
+
+	_, ok = c.Quota(7, client.RateGroupHiddenOffers)
+	assert.False(t, ok)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+func TestYandexMarketClient_RateLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRateLimits(client.RateLimits{
+			client.RateGroupFeeds: {Requests: 2, Period: 200 * time.Millisecond},
+		}),
+	)
+
+	start := time.Now()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.ListFeeds(context.Background(), 1)
+		require.NoError(t, err)
+	}
+
+	assert.Less(t, int64(time.Since(start)), int64(50*time.Millisecond), "burst should not be limited")
+
+	// other campaign has its own quota.
+	_, err := c.ListFeeds(context.Background(), 2)
+	require.NoError(t, err)
+
+	_, err = c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(80*time.Millisecond), "third call should wait for a slot")
+
+	_, _ = c.ListFeeds(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.ListFeeds(ctx, 1)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
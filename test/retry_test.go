@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+// flakyServer fails first failures calls with given status and then responds with OK.
+func flakyServer(t *testing.T, failures int32, status int, bodies *[]string) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if bodies != nil {
+			body, _ := ioutil.ReadAll(r.Body)
+			*bodies = append(*bodies, string(body))
+		}
+
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"OK","feeds":[{"id":1}]}`))
+	}))
+
+	return server, &calls
+}
+
+func TestYandexMarketClient_Retries(t *testing.T) {
+	policy := client.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    10 * time.Millisecond,
+	}
+
+	t.Run("get is retried by default", func(t *testing.T) {
+		server, calls := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+		defer server.Close()
+
+		c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), client.WithRetryPolicy(policy))
+
+		feeds, err := c.ListFeeds(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Len(t, feeds, 1)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		server, calls := flakyServer(t, 5, http.StatusTooManyRequests, nil)
+		defer server.Close()
+
+		c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), client.WithRetryPolicy(policy))
+
+		_, err := c.ListFeeds(context.Background(), 1)
+		assert.True(t, errors.Is(err, client.ErrRateLimited))
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("post is not retried by default", func(t *testing.T) {
+		server, calls := flakyServer(t, 1, http.StatusInternalServerError, nil)
+		defer server.Close()
+
+		c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), client.WithRetryPolicy(policy))
+
+		err := c.SetOfferPrices(context.Background(), 1, []models.Offer{{ID: "1"}})
+		assert.True(t, errors.Is(err, client.ErrServerError))
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("post is retried with the same body when enabled", func(t *testing.T) {
+		var bodies []string
+
+		server, calls := flakyServer(t, 1, client.StatusEnhanceYourCalm, &bodies)
+		defer server.Close()
+
+		nonIdempotent := policy
+		nonIdempotent.RetryNonIdempotent = true
+
+		c := client.NewYandexMarketClient(client.WithAPIEndpoint(server.URL), client.WithRetryPolicy(nonIdempotent))
+
+		err := c.SetOfferPrices(context.Background(), 1, []models.Offer{{ID: "1"}})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+		require.Len(t, bodies, 2)
+		assert.NotEmpty(t, bodies[0])
+		assert.Equal(t, bodies[0], bodies[1])
+	})
+}
+
+func TestYandexMarketClient_RetriesWithoutBaseDelay(t *testing.T) {
+	server, calls := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, MaxDelay: time.Minute}),
+	)
+
+	start := time.Now()
+
+	_, err := c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second), "zero base delay should not wait max delay")
+}
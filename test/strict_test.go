@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/fake"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+)
+
+const driftedPricesResponse = `{
+	"status": "OK",
+	"result": {
+		"offers": [
+			{"feed": {"id": 1}, "id": "a", "price": {"currencyId": "RUR", "value": 10, "vat": 7}, "marketSku": 5}
+		]
+	}
+}`
+
+func TestStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(driftedPricesResponse))
+	}))
+	defer server.Close()
+
+	core, logs := observer.New(zap.WarnLevel)
+
+	var drifts []client.SchemaDrift
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithLogger(zap.New(core)),
+		client.WithStrictDecoding(client.StrictDecoding{
+			ReportMissing: true,
+			Callback: func(drift client.SchemaDrift) {
+				drifts = append(drifts, drift)
+			},
+		}),
+	)
+
+	prices, err := c.GetOfferPrices(context.Background(), 1)
+	require.NoError(t, err, "drift should not fail the call")
+	require.Len(t, prices, 1)
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "GetOfferPrices", drifts[0].Operation)
+	assert.Equal(t, []string{"result.offers[].marketSku", "result.offers[].price.vat"}, drifts[0].UnknownFields)
+	assert.Equal(t, []string{
+		"errors",
+		"result.offers[].feed.content",
+		"result.offers[].feed.download",
+		"result.offers[].feed.placement",
+		"result.offers[].feed.publication",
+		"result.offers[].feed.url",
+		"result.offers[].updatedAt",
+		"result.total",
+	}, drifts[0].MissingFields)
+	assert.Equal(t, 1, logs.FilterMessage("yandex market response schema drift").Len())
+}
+
+func TestStrictDecoding_fail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(driftedPricesResponse))
+	}))
+	defer server.Close()
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithStrictDecoding(client.StrictDecoding{Fail: true}),
+	)
+
+	_, err := c.GetOfferPrices(context.Background(), 1)
+	require.True(t, errors.Is(err, client.ErrSchemaDrift))
+
+	var driftErr *client.SchemaDriftError
+	require.True(t, errors.As(err, &driftErr))
+	assert.Len(t, driftErr.UnknownFields, 2)
+	assert.Empty(t, driftErr.MissingFields, "missing fields should be reported only if enabled")
+}
+
+// TestStrictDecoding_fake is a contract check of fake server responses.
+func TestStrictDecoding_fake(t *testing.T) {
+	server := fake.NewServer()
+	defer server.Close()
+
+	server.AddFeeds(1, models.Feed{ID: 1})
+	server.AddOffers(1, models.OfferExploreModel{ID: "a"})
+
+	c := newFakeClient(server, client.WithStrictDecoding(client.StrictDecoding{Fail: true}))
+	ctx := context.Background()
+
+	_, err := c.ListFeeds(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetOfferPrices(ctx, 1, []models.Offer{{Feed: models.FeedObj{ID: 1}, ID: "a"}}))
+
+	_, err = c.GetOfferPrices(ctx, 1)
+	require.NoError(t, err)
+
+	_, err = c.GetHiddenOffers(ctx, 1)
+	require.NoError(t, err)
+
+	_, err = c.ExploreOffers(ctx, 1)
+	require.NoError(t, err)
+}
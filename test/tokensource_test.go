@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+)
+
+func TestYandexMarketClient_TokenSource(t *testing.T) {
+	var (
+		issued       int32
+		refreshToken = "refresh-0"
+	)
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, refreshToken, r.PostForm.Get("refresh_token"), "rotated refresh token should be used")
+
+		n := atomic.AddInt32(&issued, 1)
+		refreshToken = fmt.Sprintf("refresh-%d", n)
+
+		_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"%s","token_type":"bearer","expires_in":3600}`,
+			n, refreshToken)
+	}))
+	defer tokenServer.Close()
+
+	// API accepts only the second issued token, as if the first one was revoked.
+	var apiCalls int32
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"feeds":[]}`))
+	}))
+	defer apiServer.Close()
+
+	source := client.NewRefreshTokenSource("client-id", "secret", refreshToken)
+	source.Endpoint = tokenServer.URL
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(apiServer.URL),
+		client.WithTokenSource(source),
+	)
+
+	_, err := c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&apiCalls))
+
+	// token is cached.
+	_, err = c.ListFeeds(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&apiCalls))
+}
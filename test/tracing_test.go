@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/KazanExpress/yandex-market/pkg/market/client"
+	"github.com/KazanExpress/yandex-market/pkg/market/models"
+	"github.com/KazanExpress/yandex-market/pkg/market/tracing"
+)
+
+func TestYandexMarketClient_Tracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ERROR","errors":[{"code":"BAD_PRICE","message":"bad price"}]}`))
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithTracer(tracing.NewOTelTracer(provider)),
+	)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "reprice")
+
+	err := c.SetOfferPrices(ctx, 5, []models.Offer{{ID: "1"}, {ID: "2"}})
+	require.Error(t, err)
+
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	assert.Equal(t, "SetOfferPrices", span.Name)
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	assert.Equal(t, codes.Error, span.Status.Code)
+
+	attrs := attribute.NewSet(span.Attributes...)
+
+	for key, want := range map[attribute.Key]attribute.Value{
+		tracing.AttributeCampaignID: attribute.Int64Value(5),
+		tracing.AttributeItems:      attribute.IntValue(2),
+		tracing.AttributeHTTPStatus: attribute.IntValue(http.StatusOK),
+		tracing.AttributeAPIStatus:  attribute.StringValue("ERROR"),
+		tracing.AttributeErrorCodes: attribute.StringSliceValue([]string{"BAD_PRICE"}),
+	} {
+		got, ok := attrs.Value(key)
+		require.True(t, ok, "attribute %s should be set", key)
+		assert.Equal(t, want.Emit(), got.Emit(), "attribute %s", key)
+	}
+}
+
+func TestYandexMarketClient_TracingBusiness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := client.NewYandexMarketClient(
+		client.WithAPIEndpoint(server.URL),
+		client.WithTracer(tracing.NewOTelTracer(provider)),
+	)
+
+	require.NoError(t, c.SetBusinessOfferPrices(context.Background(), 3, []models.BusinessOfferPrice{{OfferID: "a"}}))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	attrs := attribute.NewSet(spans[0].Attributes...)
+
+	got, ok := attrs.Value(tracing.AttributeBusinessID)
+	require.True(t, ok)
+	assert.Equal(t, int64(3), got.AsInt64())
+
+	_, ok = attrs.Value(tracing.AttributeCampaignID)
+	assert.False(t, ok, "business span should not have campaign id")
+}